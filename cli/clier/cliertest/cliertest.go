@@ -0,0 +1,342 @@
+// Package cliertest is the conformance suite of clier.Applicationer implementations.
+//
+// It is used by backends tests only. Ex:
+//
+//	func TestConformance(t *testing.T) {
+//		cliertest.RunConformance(t, cliertest.ConformanceBackend{
+//			New:  func() clier.Applicationer { return New("myapp", "") },
+//			Args: cliertest.CommandLineArgs,
+//		})
+//	}
+package cliertest
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// ConformanceBackend describes an clier.Applicationer implementation to RunConformance.
+type ConformanceBackend struct {
+	// New returns a new empty application.
+	New func() clier.Applicationer
+	// Args returns the backend command line selecting cmds, followed by params.
+	Args func(cmds []string, params ...ConformanceParam) []string
+}
+
+// ConformanceParam is a flag or an argument value given on the command line.
+type ConformanceParam struct {
+	Name  string
+	Value string
+	Flag  bool // false for an argument.
+	Bool  bool // true for a boolean flag.
+}
+
+// Flag returns a flag ConformanceParam.
+func Flag(name, value string) ConformanceParam {
+	return ConformanceParam{Name: name, Value: value, Flag: true}
+}
+
+// BoolFlag returns a boolean flag ConformanceParam set to true.
+func BoolFlag(name string) ConformanceParam {
+	return ConformanceParam{Name: name, Value: "true", Flag: true, Bool: true}
+}
+
+// Arg returns an argument ConformanceParam. Arguments are given in declaration order.
+func Arg(name, value string) ConformanceParam {
+	return ConformanceParam{Name: name, Value: value}
+}
+
+// CommandLineArgs is the ConformanceBackend.Args of real command line parsers.
+//
+// Flags are given as '--name value', boolean flags as '--name' and arguments by position.
+func CommandLineArgs(cmds []string, params ...ConformanceParam) []string {
+	args := append([]string{}, cmds...)
+	for _, param := range params {
+		switch {
+		case param.Bool:
+			args = append(args, "--"+param.Name)
+		case param.Flag:
+			args = append(args, "--"+param.Name, param.Value)
+		default:
+			args = append(args, param.Value)
+		}
+	}
+	return args
+}
+
+// Environment variables used by the conformance suite.
+const (
+	conformanceFlagEnvar = "CLIER_CONFORMANCE_FLAG"
+	conformanceArgEnvar  = "CLIER_CONFORMANCE_ARG"
+)
+
+// RunConformance runs the clier conformance suite on a backend.
+//
+// Every clier.Applicationer implementation is expected to pass it, so that mocks and real backends behave the same way.
+// Flags and arguments are declared on the selected command.
+func RunConformance(t *testing.T, b ConformanceBackend) {
+	t.Run("Commands", func(t *testing.T) { conformanceCommands(t, b) })
+	t.Run("Flags", func(t *testing.T) { conformanceFlags(t, b) })
+	t.Run("Args", func(t *testing.T) { conformanceArgs(t, b) })
+	t.Run("EnvarDefaultPrecedence", func(t *testing.T) { conformancePrecedence(t, b) })
+	t.Run("IsInvalidContext", func(t *testing.T) { conformanceInvalidContext(t, b) })
+	t.Run("UnknownFlags", func(t *testing.T) { conformanceUnknownFlags(t, b) })
+}
+
+// conformanceApp returns a new application with 'add repo' and 'del' commands.
+func conformanceApp(b ConformanceBackend) (app clier.Applicationer, add, repo clier.CmdClauser) {
+	app = b.New()
+	add = app.Command("add", "add help")
+	repo = add.Command("repo", "repo help")
+	app.Command("del", "del help")
+	return
+}
+
+// conformanceValue returns a context value as a string. Repeatable values are comma separated.
+func conformanceValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case *string:
+		if value != nil {
+			return *value
+		}
+	case []string:
+		return strings.Join(value, ",")
+	}
+	return ""
+}
+
+func conformanceCommands(t *testing.T, b ConformanceBackend) {
+	t.Log("Expect commands to be selected by the context and by Parse.")
+
+	// --- Setting test context ---
+	app, add, repo := conformanceApp(b)
+	args := b.Args([]string{"add", "repo"})
+
+	// --- Run the test ---
+	context, err := app.ParseContext(args)
+
+	// --- Start testing ---
+	if err != nil || context == nil {
+		t.Errorf("Expected ParseContext() to work. Got %s", err)
+		return
+	}
+	cmds := context.SelectedCommands()
+	if len(cmds) != 2 {
+		t.Errorf("Expected 2 selected commands. Got %d", len(cmds))
+		return
+	}
+	if !cmds[0].IsEqualTo(add) || !cmds[1].IsEqualTo(repo) || cmds[1].IsEqualTo(add) {
+		t.Error("Expected selected commands to be 'add' and 'repo'.")
+	}
+
+	// --- Run the test ---
+	cmd, err := app.Parse(args)
+
+	// --- Start testing ---
+	if err != nil || cmd != "add repo" {
+		t.Errorf("Expected Parse() to return 'add repo'. Got '%s' (%s)", cmd, err)
+	}
+}
+
+func conformanceFlags(t *testing.T, b ConformanceBackend) {
+	t.Log("Expect flags values to be found in the context and set by Parse.")
+
+	// --- Setting test context ---
+	app, _, repo := conformanceApp(b)
+	title_flag := repo.Flag("title", "title help")
+	title := title_flag.String()
+	count := repo.Flag("count", "count help").Int()
+	debug := repo.Flag("debug", "debug help").Bool()
+	unset_flag := repo.Flag("unset", "unset help")
+	unset_flag.String()
+	args := b.Args([]string{"add", "repo"}, Flag("title", "my title"), Flag("count", "3"), BoolFlag("debug"))
+
+	// --- Run the test ---
+	context, err := app.ParseContext(args)
+
+	// --- Start testing ---
+	if err != nil || context == nil {
+		t.Errorf("Expected ParseContext() to work. Got %s", err)
+		return
+	}
+	if v, found := context.GetFlagValue(title_flag); !found || conformanceValue(v) != "my title" {
+		t.Errorf("Expected flag 'title' context value to be 'my title'. Got '%v' (%t)", v, found)
+	}
+	if v, found := context.GetFlagValue(unset_flag); found {
+		t.Errorf("Expected flag 'unset' to not be found. Got '%v'", v)
+	}
+
+	// --- Run the test ---
+	_, err = app.Parse(args)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got %s", err)
+		return
+	}
+	if *title != "my title" || *count != 3 || !*debug {
+		t.Errorf("Expected flags to be set. Got title='%s', count=%d, debug=%t", *title, *count, *debug)
+	}
+}
+
+func conformanceArgs(t *testing.T, b ConformanceBackend) {
+	t.Log("Expect arguments values to be found in the context and set by Parse.")
+
+	// --- Setting test context ---
+	app, _, repo := conformanceApp(b)
+	name_arg := repo.Arg("name", "name help")
+	name := name_arg.String()
+	owner_arg := repo.Arg("owner", "owner help")
+	owner := owner_arg.String()
+	args := b.Args([]string{"add", "repo"}, Arg("name", "myrepo"), Arg("owner", "me"))
+
+	// --- Run the test ---
+	context, err := app.ParseContext(args)
+
+	// --- Start testing ---
+	if err != nil || context == nil {
+		t.Errorf("Expected ParseContext() to work. Got %s", err)
+		return
+	}
+	if v, found := context.GetArgValue(name_arg); !found || conformanceValue(v) != "myrepo" {
+		t.Errorf("Expected arg 'name' context value to be 'myrepo'. Got '%v' (%t)", v, found)
+	}
+	if v, found := context.GetArgValue(owner_arg); !found || conformanceValue(v) != "me" {
+		t.Errorf("Expected arg 'owner' context value to be 'me'. Got '%v' (%t)", v, found)
+	}
+
+	// --- Run the test ---
+	_, err = app.Parse(args)
+
+	// --- Start testing ---
+	if err != nil || *name != "myrepo" || *owner != "me" {
+		t.Errorf("Expected args to be set. Got name='%s', owner='%s' (%v)", *name, *owner, err)
+	}
+}
+
+func conformancePrecedence(t *testing.T, b ConformanceBackend) {
+	t.Log("Expect context values and sources to come from the cli, or if missing, ENV or if missing, defaults.")
+
+	tests := []struct {
+		envar    string
+		cli      bool
+		expected string
+		source   string
+	}{
+		{"", false, "default", clier.SourceDefault},
+		{"envar", false, "envar", clier.SourceEnvar},
+		{"cli", true, "cli", clier.SourceCli},
+	}
+	defer os.Unsetenv(conformanceFlagEnvar)
+	defer os.Unsetenv(conformanceArgEnvar)
+
+	for _, test := range tests {
+		// --- Setting test context ---
+		app, _, repo := conformanceApp(b)
+		flag := repo.Flag("flag", "flag help")
+		flag.String()
+		flag.Envar(conformanceFlagEnvar)
+		flag.Default("default")
+		arg := repo.Arg("arg", "arg help")
+		arg.String()
+		arg.Envar(conformanceArgEnvar)
+		arg.Default("default")
+		os.Setenv(conformanceFlagEnvar, test.envar)
+		os.Setenv(conformanceArgEnvar, test.envar)
+		var params []ConformanceParam
+		if test.cli {
+			params = append(params, Flag("flag", "cli"), Arg("arg", "cli"))
+		}
+
+		// --- Run the test ---
+		context, err := app.ParseContext(b.Args([]string{"add", "repo"}, params...))
+
+		// --- Start testing ---
+		if err != nil || context == nil {
+			t.Errorf("Expected ParseContext() to work. Got %s", err)
+			continue
+		}
+		if v, found := context.GetFlagValue(flag); !found || conformanceValue(v) != test.expected {
+			t.Errorf("Expected flag context value to be '%s'. Got '%v' (%t)", test.expected, v, found)
+		}
+		if v, found := context.GetArgValue(arg); !found || conformanceValue(v) != test.expected {
+			t.Errorf("Expected arg context value to be '%s'. Got '%v' (%t)", test.expected, v, found)
+		}
+		if source := context.GetFlagSource(flag); source != test.source {
+			t.Errorf("Expected flag context value source to be '%s'. Got '%s'", test.source, source)
+		}
+		if source := context.GetArgSource(arg); source != test.source {
+			t.Errorf("Expected arg context value source to be '%s'. Got '%s'", test.source, source)
+		}
+	}
+}
+
+func conformanceInvalidContext(t *testing.T, b ConformanceBackend) {
+	t.Log("Expect IsInvalidContext() to be false on a parsed context and true on a nil context.")
+
+	// --- Setting test context ---
+	app, _, _ := conformanceApp(b)
+
+	// --- Run the test ---
+	context, err := app.ParseContext(b.Args([]string{"add", "repo"}))
+
+	// --- Start testing ---
+	if err != nil || context == nil {
+		t.Errorf("Expected ParseContext() to work. Got %s", err)
+		return
+	}
+	if context.IsInvalidContext() {
+		t.Error("Expected the parsed context to be valid.")
+	}
+	nil_context, ok := reflect.Zero(reflect.TypeOf(context)).Interface().(clier.ParseContexter)
+	if ok && !nil_context.IsInvalidContext() {
+		t.Error("Expected a nil context to be invalid.")
+	}
+}
+
+func conformanceUnknownFlags(t *testing.T, b ConformanceBackend) {
+	t.Log("Expect ParseContext() to tolerate flags declared later from the context.")
+
+	// --- Setting test context ---
+	app, _, repo := conformanceApp(b)
+	title := repo.Flag("title", "title help")
+	title.String()
+	args := b.Args([]string{"add", "repo"}, Flag("title", "my title"), Flag("later", "value"))
+
+	// --- Run the test ---
+	context, _ := app.ParseContext(args)
+
+	// --- Start testing ---
+	if context == nil || context.IsInvalidContext() {
+		t.Error("Expected ParseContext() to return a valid context.")
+		return
+	}
+	if cmds := context.SelectedCommands(); len(cmds) != 2 {
+		t.Errorf("Expected 2 selected commands. Got %d", len(cmds))
+	}
+	if v, found := context.GetFlagValue(title); !found || conformanceValue(v) != "my title" {
+		t.Errorf("Expected flag 'title' context value to be 'my title'. Got '%v' (%t)", v, found)
+	}
+
+	// --- Setting test context ---
+	later := repo.Flag("later", "later help")
+	later.String()
+
+	// --- Run the test ---
+	context, err := app.ParseContext(args)
+
+	// --- Start testing ---
+	if err != nil || context == nil {
+		t.Errorf("Expected ParseContext() to work once the flag is declared. Got %s", err)
+		return
+	}
+	if v, found := context.GetFlagValue(later); !found || conformanceValue(v) != "value" {
+		t.Errorf("Expected flag 'later' context value to be 'value'. Got '%v' (%t)", v, found)
+	}
+}
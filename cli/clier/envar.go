@@ -0,0 +1,14 @@
+package clier
+
+import "regexp"
+
+// envarValueSplitter split repeatable params environment variable values, like kingpin do.
+var envarValueSplitter = regexp.MustCompile(`\r?\n`)
+
+// EnvarValues return the list of values of a repeatable param environment variable. Values are newline separated.
+func EnvarValues(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return envarValueSplitter.Split(value, -1)
+}
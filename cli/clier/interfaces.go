@@ -1,5 +1,10 @@
 package clier
 
+import (
+	"net/url"
+	"time"
+)
+
 // Define interface against kingpin or kingpinMock (for go test)
 
 type Applicationer interface {
@@ -16,6 +21,13 @@ type FlagClauser interface {
 	Stringer() string
 	String() *string
 	Bool() *bool
+	Int() *int
+	Float64() *float64
+	Duration() *time.Duration
+	Enum(...string) *string
+	URL() **url.URL
+	Strings() *[]string
+	StringMap() *map[string]string
 	Required() FlagClauser
 	Short(rune) FlagClauser
 	Hidden() FlagClauser
@@ -28,6 +40,11 @@ type ArgClauser interface {
 	Stringer() string
 	String() *string
 	Bool() *bool
+	Int() *int
+	Float64() *float64
+	Duration() *time.Duration
+	Enum(...string) *string
+	URL() **url.URL
 	Required() ArgClauser
 	Default(string) ArgClauser
 	SetValue(Valuer) ArgClauser
@@ -42,9 +59,20 @@ type CmdClauser interface {
 	IsEqualTo(CmdClauser) bool
 }
 
+// Context values sources, as reported by ParseContexter GetArgSource and GetFlagSource.
+const (
+	SourceCli     = "cli"
+	SourceEnvar   = "envar"
+	SourceDefault = "default"
+)
+
 type ParseContexter interface {
 	GetArgValue(ArgClauser) (interface{}, bool)
 	GetFlagValue(FlagClauser) (interface{}, bool)
+	// GetArgSource and GetFlagSource return which source gave the context value: SourceCli, SourceEnvar or
+	// SourceDefault. An empty string is returned if there is no value.
+	GetArgSource(ArgClauser) string
+	GetFlagSource(FlagClauser) string
 	GetParam(string) (interface{}, string)
 	SelectedCommands() []CmdClauser
 	IsInvalidContext() bool
@@ -0,0 +1,178 @@
+// Package clitest runs whole forjj command lines and compares the parse results against golden YAML files.
+//
+// Ex:
+//
+//	func TestCreateRepo(t *testing.T) {
+//		clitest.Run(t, newCli, clitest.Cases{
+//			{Name: "create-repo", Args: []string{"create", "repo", "--name", "myrepo"}},
+//			{Name: "create-repo-envar", Args: []string{"create", "repo"}, Env: map[string]string{"REPO_NAME": "myrepo"}},
+//		})
+//	}
+//
+// Golden files are written in testdata/<case name>.yaml with 'go test -clitest.update'.
+package clitest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli"
+	"gopkg.in/yaml.v2"
+)
+
+// Update is set by 'go test -clitest.update'. Golden files are written instead of compared.
+//
+// The flag is prefixed to not conflict with an '-update' flag of the test package.
+var Update = flag.Bool("clitest.update", false, "update clitest golden files")
+
+// GoldenDir is the directory of golden files, relative to the test package.
+var GoldenDir = "testdata"
+
+// Builder returns a new ForjCli with all actions, objects and flags declared. It is called once per case.
+type Builder func() *cli.ForjCli
+
+// Case is a command line to parse. Env is set during the parse only.
+type Case struct {
+	Name string            // Golden file name, without extension.
+	Args []string          // Command line arguments, as given to ForjCli.Parse.
+	Env  map[string]string // Environment variables.
+	Data interface{}       // Data given to ForjCli.Parse.
+}
+
+// Cases is a table of command lines.
+type Cases []Case
+
+// Result is the golden file content of a Case.
+type Result struct {
+	Args    []string           `yaml:"args"`
+	Env     map[string]string  `yaml:"env,omitempty"`
+	Command string             `yaml:"command,omitempty"`
+	Action  string             `yaml:"action,omitempty"`
+	Object  string             `yaml:"object,omitempty"`
+	List    string             `yaml:"list,omitempty"`
+	Errors  []ResultError      `yaml:"errors,omitempty"`
+	Values  *cli.ForjValuesDoc `yaml:"values"`
+}
+
+// ResultError is a parse error. Code is set for typed cli errors. (See cli.GetErrorCode)
+type ResultError struct {
+	Code    string `yaml:"code,omitempty"`
+	Message string `yaml:"message"`
+}
+
+// Run parses each case with a new ForjCli and compares the result with its golden file.
+//
+// With 'go test -clitest.update', golden files are written instead.
+func Run(t *testing.T, build Builder, cases Cases) {
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			data, err := c.Run(build).YAML()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := CompareGolden(path.Join(GoldenDir, c.Name+".yaml"), data, *Update); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Run parses the case command line with a new ForjCli and returns the result.
+func (c Case) Run(build Builder) *Result {
+	restore := setEnv(c.Env)
+	defer restore()
+
+	forj := build()
+	r := &Result{Args: c.Args, Env: c.Env}
+	cmd, err := forj.Parse(c.Args, c.Data)
+	r.Command = cmd
+	r.Action = forj.GetSelectedAction()
+	r.Object = forj.GetSelectedObject()
+	r.List = forj.GetSelectedList()
+	for _, e := range cli.GetErrors(err) {
+		r.Errors = append(r.Errors, ResultError{Code: string(cli.GetErrorCode(e)), Message: e.Error()})
+	}
+	r.Values = forj.ExportValues()
+	return r
+}
+
+// YAML returns the result as a YAML document.
+func (r *Result) YAML() ([]byte, error) {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode the result. %s", err)
+	}
+	return data, nil
+}
+
+// CompareGolden compares data with the golden file content. If update is true, the golden file is written instead.
+func CompareGolden(file string, data []byte, update bool) error {
+	if update {
+		if err := os.MkdirAll(path.Dir(file), 0755); err != nil {
+			return fmt.Errorf("Unable to create the golden files directory. %s", err)
+		}
+		if err := ioutil.WriteFile(file, data, 0644); err != nil {
+			return fmt.Errorf("Unable to update golden file '%s'. %s", file, err)
+		}
+		return nil
+	}
+	golden, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("Unable to read golden file '%s'. %s. Run 'go test -clitest.update' to create it.", file, err)
+	}
+	if !bytes.Equal(golden, data) {
+		return fmt.Errorf("Result differs from golden file '%s'.\n%s", file, diffLines(string(golden), string(data)))
+	}
+	return nil
+}
+
+// diffLines returns the lines which differ between expected and got, prefixed by '-' and '+'.
+func diffLines(expected, got string) string {
+	e_lines := strings.Split(expected, "\n")
+	g_lines := strings.Split(got, "\n")
+	var ret []string
+	for i := 0; i < len(e_lines) || i < len(g_lines); i++ {
+		var e, g string
+		if i < len(e_lines) {
+			e = e_lines[i]
+		}
+		if i < len(g_lines) {
+			g = g_lines[i]
+		}
+		if e == g {
+			continue
+		}
+		ret = append(ret, fmt.Sprintf("line %d:", i+1), "- "+e, "+ "+g)
+	}
+	return strings.Join(ret, "\n")
+}
+
+// setEnv sets environment variables. The returned function restores them.
+func setEnv(env map[string]string) (restore func()) {
+	saved := make(map[string]*string)
+	for name, value := range env {
+		if v, found := os.LookupEnv(name); found {
+			saved[name] = &v
+		} else {
+			saved[name] = nil
+		}
+		os.Setenv(name, value)
+	}
+	return func() {
+		for name, v := range saved {
+			if v == nil {
+				os.Unsetenv(name)
+			} else {
+				os.Setenv(name, *v)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package clitest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli"
+	"github.com/forj-oss/forjj-modules/cli/kingpinMock"
+)
+
+// A test package can declare its own '-update' flag. (clitest uses '-clitest.update')
+var _ = flag.Bool("update", false, "update test package golden files")
+
+func TestRun(t *testing.T) {
+	t.Log("Expect command lines results to match golden files.")
+
+	// --- Setting test context ---
+	build := func() *cli.ForjCli {
+		c := cli.NewForjCli(kingpinMock.New("Application"))
+		c.AddFieldListCapture("w", `[a-z]+[a-z0-9_-]*`)
+		c.NewActions("create", "create help", "create %s", true)
+		c.NewObject("repo", "repo help", "").
+			AddKey(cli.String, "name", "name help", "#w", nil).
+			AddField(cli.String, "title", "title help", "", cli.Opts().Envar("CLITEST_TITLE")).
+			AddField(cli.Int, "port", "port help", "", cli.Opts().Default("8080")).
+			DefineActions("create").OnActions().
+			AddFlag("name", cli.Opts().Required()).
+			AddFlag("title", nil).
+			AddFlag("port", nil).
+			CreateList("to_create", ",", "name", "repos help").
+			AddActions("create")
+		return c
+	}
+
+	// --- Run the test ---
+	Run(t, build, Cases{
+		{Name: "create-repo", Args: []string{"cmd:create", "cmd:repo", "name", "myrepo", "title", "My repo"}},
+		{Name: "create-repo-envar", Args: []string{"cmd:create", "cmd:repo", "name", "myrepo"},
+			Env: map[string]string{"CLITEST_TITLE": "From envar"}},
+		{Name: "create-repos", Args: []string{"cmd:create", "cmd:repos", "repos", "repo1,repo2"}},
+		{Name: "create-repo-invalid-port", Args: []string{"cmd:create", "cmd:repo", "name", "myrepo", "port", "abc"}},
+	})
+}
+
+func TestCase_Run(t *testing.T) {
+	t.Log("Expect Case.Run() to report the selection, values and environment.")
+
+	// --- Setting test context ---
+	build := func() *cli.ForjCli {
+		c := cli.NewForjCli(kingpinMock.New("Application"))
+		c.AddFieldListCapture("w", `[a-z]+[a-z0-9_-]*`)
+		c.NewActions("create", "create help", "create %s", true)
+		c.NewObject("repo", "repo help", "").
+			AddKey(cli.String, "name", "name help", "#w", nil).
+			AddField(cli.String, "title", "title help", "", cli.Opts().Envar("CLITEST_TITLE")).
+			AddField(cli.Int, "port", "port help", "", cli.Opts().Default("8080")).
+			DefineActions("create").OnActions().
+			AddFlag("name", cli.Opts().Required()).
+			AddFlag("title", nil).
+			AddFlag("port", nil).
+			CreateList("to_create", ",", "name", "repos help").
+			AddActions("create")
+		return c
+	}
+	c := Case{Name: "test", Args: []string{"cmd:create", "cmd:repo", "name", "myrepo"},
+		Env: map[string]string{"CLITEST_TITLE": "From envar"}}
+
+	// --- Run the test ---
+	r := c.Run(build)
+
+	// --- Start testing ---
+	if r.Action != "create" || r.Object != "repo" || r.List != "" {
+		t.Errorf("Expected 'create repo' to be selected. Got action '%s', object '%s', list '%s'",
+			r.Action, r.Object, r.List)
+	}
+	if len(r.Errors) != 0 {
+		t.Errorf("Expected no errors. Got %v", r.Errors)
+	}
+	if v := r.Values.Objects["repo"]["myrepo"].Attributes["title"].Value; v != "From envar" {
+		t.Errorf("Expected title to be set from the environment. Got '%v'", v)
+	}
+	if _, found := os.LookupEnv("CLITEST_TITLE"); found {
+		t.Error("Expected the environment to be restored.")
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	t.Log("Expect CompareGolden() to write golden files in update mode and to report differences.")
+
+	// --- Setting test context ---
+	dir, err := ioutil.TempDir("", "clitest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "testdata", "case.yaml")
+
+	// --- Run the test ---
+	err = CompareGolden(file, []byte("a: 1\nb: 2\n"), true)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected the golden file to be written. Got %s", err)
+		return
+	}
+	if err = CompareGolden(file, []byte("a: 1\nb: 2\n"), false); err != nil {
+		t.Errorf("Expected the same data to match. Got %s", err)
+	}
+
+	// --- Run the test ---
+	err = CompareGolden(file, []byte("a: 1\nb: 3\n"), false)
+
+	// --- Start testing ---
+	if err == nil || !strings.Contains(err.Error(), "line 2:\n- b: 2\n+ b: 3") {
+		t.Errorf("Expected line 2 difference to be reported. Got %v", err)
+	}
+	if err = CompareGolden(path.Join(dir, "missing.yaml"), nil, false); err == nil {
+		t.Error("Expected a missing golden file to fail.")
+	}
+}
@@ -0,0 +1,81 @@
+package cobraCli
+
+import (
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/spf13/cobra"
+)
+
+type Application struct {
+	app      *cobra.Command
+	root     *CmdClause
+	name     string
+	selected *CmdClause // Command selected by the last Parse.
+}
+
+// New creates the application interface on a cobra root command.
+//
+// Like kingpin Parse, errors are not printed by cobra but returned by Parse.
+func New(app *cobra.Command, name string) *Application {
+	a := &Application{app: app, name: name}
+	if app != nil {
+		app.SilenceErrors = true
+		app.SilenceUsage = true
+	}
+	a.root = newCmdClause(a, nil, app)
+	return a
+}
+
+func (a *Application) IsNil() bool {
+	if a == nil {
+		return true
+	}
+	return false
+}
+
+func (a *Application) Arg(p1, p2 string) clier.ArgClauser {
+	return a.root.Arg(p1, p2)
+}
+
+func (a *Application) Flag(p1, p2 string) clier.FlagClauser {
+	return a.root.Flag(p1, p2)
+}
+
+func (a *Application) Command(p1, p2 string) clier.CmdClauser {
+	return a.root.Command(p1, p2)
+}
+
+// ParseContext parses args with the current definition, without setting any values.
+//
+// Flags not declared yet are ignored, as they can be added later from the context. (See ParseContext)
+func (a *Application) ParseContext(args []string) (p clier.ParseContexter, err error) {
+	context := newParseContext(a)
+	err = context.parse(args)
+	context.err = err
+	p = context
+	return
+}
+
+// Parse parses args with cobra and set all flags and arguments values. It returns the selected command full name.
+func (a *Application) Parse(args []string) (cmd string, err error) {
+	if err = a.root.install(); err != nil {
+		return
+	}
+	a.selected = nil
+	a.app.SetArgs(append([]string{}, args...))
+	if _, err = a.app.ExecuteC(); err != nil || a.selected == nil {
+		// No command selected without error means help has been displayed.
+		return
+	}
+	return a.selected.FullCommand(), nil
+}
+
+func (a *Application) Name() string {
+	if a == nil {
+		return ""
+	}
+	return a.name
+}
+
+func (a *Application) GetApp() *cobra.Command {
+	return a.app
+}
@@ -0,0 +1,113 @@
+package cobraCli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestNilDetection(t *testing.T) {
+	var v *Application
+
+	t.Log("without new application, expect app.IsNil() to be true")
+	if !v.IsNil() {
+		t.Error("fail: ", v)
+	}
+	v = New(&cobra.Command{Use: "test"}, "myapp")
+	t.Log("with a new application, expect app.IsNil() to be false")
+	if v.IsNil() {
+		t.Error("fail ", v)
+	}
+}
+
+func TestApplication_Name(t *testing.T) {
+	const app_name = "myapp"
+
+	t.Log("Expect Name() to return the cobraCli application name.")
+	app := New(&cobra.Command{Use: "test"}, app_name)
+
+	if v := app.Name(); v != app_name {
+		t.Errorf("Expected app to be named '%s'. Got '%s'", app_name, v)
+	}
+	if app.GetApp() == nil {
+		t.Error("Expected cobra command to exist. Got Nil.")
+	}
+}
+
+func TestApplication_Parse(t *testing.T) {
+	t.Log("Expect Parse() to set flags and arguments values and return the selected command.")
+
+	const (
+		test_help = "test help"
+		envar     = "COBRACLI_TEST_ORGA"
+	)
+	// --- Setting test context ---
+	a := New(&cobra.Command{Use: "test"}, "myapp")
+	debug := a.Flag("debug", test_help).Bool()
+	c1 := a.Command("add", "")
+	c2 := c1.Command("repo", "")
+	name := c2.Arg("name", test_help).String()
+	c2.Flag("title", test_help).Short('t').Default("none")
+	title := c2.Flag("title2", test_help).String()
+	orga_flag := c2.Flag("orga", test_help)
+	orga_flag.Envar(envar)
+	orga := orga_flag.String()
+	labels_flag := c2.Flag("label", test_help)
+	labels := labels_flag.Strings()
+	labels_flag.Default("a,b")
+	os.Setenv(envar, "myorga")
+	defer os.Unsetenv(envar)
+
+	// --- Run the test ---
+	cmd, err := a.Parse([]string{"--debug", "add", "repo", "myrepo", "--title2", "my title"})
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got %s", err)
+		return
+	}
+	if cmd != "add repo" {
+		t.Errorf("Expected Parse() to return 'add repo'. Got '%s'", cmd)
+	}
+	if !*debug || *name != "myrepo" || *title != "my title" {
+		t.Errorf("Expected values to be set. Got debug=%t, name='%s', title='%s'", *debug, *name, *title)
+	}
+	if *orga != "myorga" {
+		t.Errorf("Expected orga to be set from '%s'. Got '%s'", envar, *orga)
+	}
+	if len(*labels) != 2 || (*labels)[1] != "b" {
+		t.Errorf("Expected labels defaults to be set. Got %v", *labels)
+	}
+}
+
+func TestApplication_Parse_Errors(t *testing.T) {
+	t.Log("Expect Parse() to report kingpin like errors.")
+
+	// --- Setting test context ---
+	newApp := func() *Application {
+		a := New(&cobra.Command{Use: "test"}, "myapp")
+		c1 := a.Command("add", "")
+		c2 := c1.Command("repo", "")
+		c2.Arg("name", "").Required().String()
+		c2.Flag("kind", "").Enum("a", "b")
+		return a
+	}
+	tests := [][]string{
+		{"add"},
+		{"add", "repo"},
+		{"add", "repo", "myrepo", "other"},
+		{"add", "repo", "myrepo", "--kind", "c"},
+		{"add", "repo", "myrepo", "--unknown"},
+	}
+
+	for _, args := range tests {
+		// --- Run the test ---
+		_, err := newApp().Parse(args)
+
+		// --- Start testing ---
+		if err == nil {
+			t.Errorf("Expected Parse(%v) to fail. Got no error.", args)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package cobraCli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/spf13/pflag"
+)
+
+type CArgClause interface {
+	GetArg() pflag.Value
+}
+
+// ArgClause is a cobra positional argument declared like a kingpin argument.
+//
+// cobra gives positional arguments as strings. They are set in declaration order. (See Application.Parse)
+type ArgClause struct {
+	name          string
+	help          string
+	required      bool
+	envar         string
+	enum          []string
+	default_value *string
+	value         pflag.Value // Typed value set from the command line.
+}
+
+func (a *ArgClause) Stringer() string {
+	ret := fmt.Sprintf("ArgClause (%p):\n", a)
+	ret += fmt.Sprintf("  name: '%s'\n", a.name)
+	if a.default_value == nil {
+		ret += fmt.Sprint("  vdefault: nil\n")
+	} else {
+		ret += fmt.Sprintf("  vdefault: '%s' (%p)\n", *a.default_value, a.default_value)
+	}
+	return ret
+}
+
+// newValue creates the typed arg value with a pflag definition function.
+func (a *ArgClause) newValue(define func(fs *pflag.FlagSet)) {
+	fs := pflag.NewFlagSet(a.name, pflag.ContinueOnError)
+	define(fs)
+	a.value = fs.Lookup(a.name).Value
+}
+
+func (a *ArgClause) String() *string {
+	v := new(string)
+	a.newValue(func(fs *pflag.FlagSet) { fs.StringVar(v, a.name, "", a.help) })
+	return v
+}
+
+func (a *ArgClause) Bool() *bool {
+	v := new(bool)
+	a.newValue(func(fs *pflag.FlagSet) { fs.BoolVar(v, a.name, false, a.help) })
+	return v
+}
+
+func (a *ArgClause) Int() *int {
+	v := new(int)
+	a.newValue(func(fs *pflag.FlagSet) { fs.IntVar(v, a.name, 0, a.help) })
+	return v
+}
+
+func (a *ArgClause) Float64() *float64 {
+	v := new(float64)
+	a.newValue(func(fs *pflag.FlagSet) { fs.Float64Var(v, a.name, 0, a.help) })
+	return v
+}
+
+func (a *ArgClause) Duration() *time.Duration {
+	v := new(time.Duration)
+	a.newValue(func(fs *pflag.FlagSet) { fs.DurationVar(v, a.name, 0, a.help) })
+	return v
+}
+
+func (a *ArgClause) Enum(options ...string) *string {
+	a.enum = options
+	return a.String()
+}
+
+func (a *ArgClause) URL() **url.URL {
+	v := new(*url.URL)
+	a.value = &urlValue{v}
+	return v
+}
+
+func (a *ArgClause) Required() clier.ArgClauser {
+	a.required = true
+	return a
+}
+
+func (a *ArgClause) Default(p1 string) clier.ArgClauser {
+	if a.default_value == nil {
+		a.default_value = new(string)
+	}
+	*a.default_value = p1
+	return a
+}
+
+func (f *ArgClause) getDefaults() *string {
+	return f.default_value
+}
+
+func (f *ArgClause) hasDefaults() bool {
+	return (f.default_value != nil)
+}
+
+func (a *ArgClause) Envar(p1 string) clier.ArgClauser {
+	a.envar = p1
+	return a
+}
+
+// getEnvar return the argument environment variable value, if set.
+func (a *ArgClause) getEnvar() (string, bool) {
+	if a.envar == "" {
+		return "", false
+	}
+	if v := os.Getenv(a.envar); v != "" {
+		return v, true
+	}
+	return "", false
+}
+
+func (a *ArgClause) SetValue(p1 clier.Valuer) clier.ArgClauser {
+	a.value = &valuerValue{p1}
+	return a
+}
+
+func (a *ArgClause) GetArg() pflag.Value {
+	return a.value
+}
+
+// set the argument value from the command line, or if missing, ENV or if missing, defaults.
+func (a *ArgClause) set(value string, found bool) error {
+	if a.value == nil {
+		a.String()
+	}
+	if !found {
+		if v, ok := a.getEnvar(); ok {
+			value, found = v, true
+		} else if a.default_value != nil {
+			value, found = *a.default_value, true
+		} else if a.required {
+			return fmt.Errorf("required argument '%s' not provided", a.name)
+		}
+	}
+	if !found {
+		return nil
+	}
+	if err := checkEnum(a.enum, value, "argument '"+a.name+"'"); err != nil {
+		return err
+	}
+	if err := a.value.Set(value); err != nil {
+		return fmt.Errorf("invalid value '%s' for argument '%s': %s", value, a.name, err)
+	}
+	return nil
+}
+
+// NewArg creates a generic ArgClause named name.
+func NewArg(name, help string) (a *ArgClause) {
+	a = new(ArgClause)
+	a.name = name
+	a.help = help
+	return
+}
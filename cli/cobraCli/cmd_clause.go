@@ -0,0 +1,129 @@
+package cobraCli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/spf13/cobra"
+)
+
+type CCmdClause interface {
+	GetCmd() *cobra.Command
+}
+
+// CmdClause is a cobra command with the flags and arguments declared on it.
+//
+// The application root command is a CmdClause without parent.
+type CmdClause struct {
+	cmd    *cobra.Command
+	app    *Application
+	parent *CmdClause
+	cmds   []*CmdClause
+	flags  []*FlagClause
+	args   []*ArgClause
+}
+
+func newCmdClause(app *Application, parent *CmdClause, cmd *cobra.Command) *CmdClause {
+	return &CmdClause{cmd: cmd, app: app, parent: parent}
+}
+
+func (c *CmdClause) Command(p1, p2 string) clier.CmdClauser {
+	cmd := &cobra.Command{Use: p1, Short: p2}
+	c.cmd.AddCommand(cmd)
+	sub := newCmdClause(c.app, c, cmd)
+	c.cmds = append(c.cmds, sub)
+	return sub
+}
+
+func (c *CmdClause) Flag(p1, p2 string) clier.FlagClauser {
+	f := NewFlag(p1, p2)
+	c.flags = append(c.flags, f)
+	return f
+}
+
+func (c *CmdClause) Arg(p1, p2 string) clier.ArgClauser {
+	a := NewArg(p1, p2)
+	c.args = append(c.args, a)
+	return a
+}
+
+// FullCommand return the command names from the application, like kingpin. Ex: 'add repo'
+func (c *CmdClause) FullCommand() string {
+	names := make([]string, 0, 2)
+	for cmd := c; cmd != nil && cmd.parent != nil; cmd = cmd.parent {
+		names = append([]string{cmd.cmd.Name()}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
+func (c *CmdClause) GetCmd() *cobra.Command {
+	return c.cmd
+}
+
+func (c *CmdClause) IsEqualTo(c_ref clier.CmdClauser) bool {
+	ref, ok := c_ref.(*CmdClause)
+	return ok && c.cmd == ref.cmd
+}
+
+// getCommand return the sub command named name.
+func (c *CmdClause) getCommand(name string) *CmdClause {
+	for _, cmd := range c.cmds {
+		if cmd.cmd.Name() == name || cmd.cmd.HasAlias(name) {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// install add new flags to the cobra commands tree. Flags are persistent, as kingpin flags are inherited by sub
+// commands.
+func (c *CmdClause) install() error {
+	for _, f := range c.flags {
+		if err := f.install(c.cmd.PersistentFlags()); err != nil {
+			return err
+		}
+	}
+	c.cmd.Args = cobra.ArbitraryArgs
+	c.cmd.RunE = c.run
+	for _, cmd := range c.cmds {
+		if err := cmd.install(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run is the cobra command function. It sets arguments, environment values and check flags of the selected command.
+func (c *CmdClause) run(_ *cobra.Command, args []string) error {
+	if len(c.cmds) > 0 {
+		if len(args) > 0 {
+			return fmt.Errorf("expected command but got %q", args[0])
+		}
+		if c.parent == nil {
+			return fmt.Errorf("command not specified")
+		}
+		return fmt.Errorf("must select a subcommand of %q", c.FullCommand())
+	}
+	if len(args) > len(c.args) {
+		return fmt.Errorf("unexpected %s", args[len(c.args)])
+	}
+	for i, arg := range c.args {
+		if i < len(args) {
+			if err := arg.set(args[i], true); err != nil {
+				return err
+			}
+		} else if err := arg.set("", false); err != nil {
+			return err
+		}
+	}
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		for _, f := range cmd.flags {
+			if err := f.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	c.app.selected = c
+	return nil
+}
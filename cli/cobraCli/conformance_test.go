@@ -0,0 +1,17 @@
+package cobraCli
+
+import (
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/cli/clier/cliertest"
+	"github.com/spf13/cobra"
+)
+
+func TestConformance(t *testing.T) {
+	t.Log("Expect cobraCli to pass the clier conformance suite.")
+	cliertest.RunConformance(t, cliertest.ConformanceBackend{
+		New:  func() clier.Applicationer { return New(&cobra.Command{Use: "test"}, "myapp") },
+		Args: cliertest.CommandLineArgs,
+	})
+}
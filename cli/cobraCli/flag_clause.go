@@ -0,0 +1,234 @@
+package cobraCli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/spf13/pflag"
+)
+
+type CFlagClause interface {
+	GetFlag() *pflag.Flag
+}
+
+// FlagClause is a cobra/pflag flag declared like a kingpin flag.
+//
+// pflag needs the flag shorthand and defaults when the flag is created, while kingpin set them after the flag type.
+// So, the pflag flag is created when the command line is parsed. (See Application.Parse)
+type FlagClause struct {
+	name          string
+	help          string
+	short         string
+	hidden        bool
+	required      bool
+	envar         string
+	enum          []string
+	default_value *string
+	multi         bool                    // true if the flag is repeatable. (Strings, StringMap)
+	boolean       bool                    // true if the flag do not need a value.
+	define        func(fs *pflag.FlagSet) // Create the typed pflag flag in fs.
+	flag          *pflag.Flag             // pflag flag, once created.
+}
+
+func (a *FlagClause) Stringer() string {
+	ret := fmt.Sprintf("FlagClause (%p):\n", a)
+	ret += fmt.Sprintf("  name: '%s'\n", a.name)
+	if a.default_value == nil {
+		ret += fmt.Sprint("  vdefault: nil\n")
+	} else {
+		ret += fmt.Sprintf("  vdefault: '%s' (%p)\n", *a.default_value, a.default_value)
+	}
+	return ret
+}
+
+func (f *FlagClause) String() *string {
+	v := new(string)
+	f.define = func(fs *pflag.FlagSet) { fs.StringVarP(v, f.name, f.short, "", f.help) }
+	return v
+}
+
+func (f *FlagClause) Bool() *bool {
+	v := new(bool)
+	f.boolean = true
+	f.define = func(fs *pflag.FlagSet) { fs.BoolVarP(v, f.name, f.short, false, f.help) }
+	return v
+}
+
+func (f *FlagClause) Int() *int {
+	v := new(int)
+	f.define = func(fs *pflag.FlagSet) { fs.IntVarP(v, f.name, f.short, 0, f.help) }
+	return v
+}
+
+func (f *FlagClause) Float64() *float64 {
+	v := new(float64)
+	f.define = func(fs *pflag.FlagSet) { fs.Float64VarP(v, f.name, f.short, 0, f.help) }
+	return v
+}
+
+func (f *FlagClause) Duration() *time.Duration {
+	v := new(time.Duration)
+	f.define = func(fs *pflag.FlagSet) { fs.DurationVarP(v, f.name, f.short, 0, f.help) }
+	return v
+}
+
+func (f *FlagClause) Enum(options ...string) *string {
+	f.enum = options
+	return f.String()
+}
+
+func (f *FlagClause) URL() **url.URL {
+	v := new(*url.URL)
+	f.define = func(fs *pflag.FlagSet) { fs.VarP(&urlValue{v}, f.name, f.short, f.help) }
+	return v
+}
+
+func (f *FlagClause) Strings() *[]string {
+	v := new([]string)
+	f.multi = true
+	f.define = func(fs *pflag.FlagSet) {
+		var defaults []string
+		if f.default_value != nil {
+			defaults = strings.Split(*f.default_value, ",")
+		}
+		fs.StringArrayVarP(v, f.name, f.short, defaults, f.help)
+	}
+	return v
+}
+
+func (f *FlagClause) StringMap() *map[string]string {
+	v := new(map[string]string)
+	f.multi = true
+	f.define = func(fs *pflag.FlagSet) {
+		defaults := make(map[string]string)
+		if f.default_value != nil {
+			for _, pair := range strings.Split(*f.default_value, ",") {
+				kv := strings.SplitN(pair, "=", 2)
+				if len(kv) == 2 {
+					defaults[kv[0]] = kv[1]
+				}
+			}
+		}
+		fs.StringToStringVarP(v, f.name, f.short, defaults, f.help)
+	}
+	return v
+}
+
+func (f *FlagClause) isMulti() bool {
+	return f.multi
+}
+
+func (f *FlagClause) Required() clier.FlagClauser {
+	f.required = true
+	return f
+}
+
+func (f *FlagClause) Short(p1 rune) clier.FlagClauser {
+	f.short = string(p1)
+	return f
+}
+
+func (f *FlagClause) Hidden() clier.FlagClauser {
+	f.hidden = true
+	return f
+}
+
+func (f *FlagClause) Default(p1 string) clier.FlagClauser {
+	if f.default_value == nil {
+		f.default_value = new(string)
+	}
+	*f.default_value = p1
+	return f
+}
+
+func (f *FlagClause) getDefaults() *string {
+	return f.default_value
+}
+
+func (f *FlagClause) hasDefaults() bool {
+	return (f.default_value != nil)
+}
+
+func (f *FlagClause) Envar(p1 string) clier.FlagClauser {
+	f.envar = p1
+	return f
+}
+
+// getEnvar return the flag environment variable value, if set.
+func (f *FlagClause) getEnvar() (string, bool) {
+	if f.envar == "" {
+		return "", false
+	}
+	if v := os.Getenv(f.envar); v != "" {
+		return v, true
+	}
+	return "", false
+}
+
+func (f *FlagClause) SetValue(p1 clier.Valuer) clier.FlagClauser {
+	f.define = func(fs *pflag.FlagSet) { fs.VarP(&valuerValue{p1}, f.name, f.short, f.help) }
+	return f
+}
+
+func (f *FlagClause) GetFlag() *pflag.Flag {
+	return f.flag
+}
+
+// install create the pflag flag and add it to fs. A flag is installed once.
+func (f *FlagClause) install(fs *pflag.FlagSet) error {
+	if f.flag != nil {
+		return nil
+	}
+	if f.define == nil {
+		f.String()
+	}
+	flags := pflag.NewFlagSet(f.name, pflag.ContinueOnError)
+	f.define(flags)
+	flag := flags.Lookup(f.name)
+	if f.default_value != nil && !f.multi {
+		if err := flag.Value.Set(*f.default_value); err != nil {
+			return fmt.Errorf("invalid default value '%s' for flag '--%s': %s", *f.default_value, f.name, err)
+		}
+		flag.DefValue = *f.default_value
+	}
+	flag.Hidden = f.hidden
+	fs.AddFlag(flag)
+	f.flag = flag
+	return nil
+}
+
+// validate set the flag from its environment variable if not given on the command line and check its value like
+// kingpin do.
+func (f *FlagClause) validate() error {
+	if f.flag == nil {
+		return nil
+	}
+	if !f.flag.Changed {
+		if v, found := f.getEnvar(); found {
+			values := []string{v}
+			if f.multi {
+				values = clier.EnvarValues(v)
+			}
+			for _, value := range values {
+				if err := f.flag.Value.Set(value); err != nil {
+					return fmt.Errorf("invalid value '%s' for flag '--%s' from '%s': %s", value, f.name, f.envar, err)
+				}
+			}
+		} else if f.required && f.default_value == nil {
+			return fmt.Errorf("required flag --%s not provided", f.name)
+		}
+	}
+	return checkEnum(f.enum, f.flag.Value.String(), "flag '--"+f.name+"'")
+}
+
+// NewFlag creates a generic FlagClause named name.
+func NewFlag(name, help string) (f *FlagClause) {
+	f = new(FlagClause)
+	f.name = name
+	f.help = help
+	return
+}
@@ -0,0 +1,173 @@
+package cobraCli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/spf13/pflag"
+)
+
+// ParseContext is the command line parsed with the current application definition, before cobra parses it.
+//
+// Unlike cobra, it tolerates flags which are not declared yet: they can be added from the context by the cli hooks.
+// The command line is parsed by pflag, which skips undeclared flags. (See pflag.ParseErrorsWhitelist)
+type ParseContext struct {
+	app   *Application
+	cmds  []*CmdClause
+	flags map[*FlagClause][]string
+	args  map[*ArgClause]string
+	err   error // Parse error. (See IsInvalidContext)
+}
+
+func newParseContext(app *Application) *ParseContext {
+	p := new(ParseContext)
+	p.app = app
+	p.flags = make(map[*FlagClause][]string)
+	p.args = make(map[*ArgClause]string)
+	return p
+}
+
+// parse load flags, arguments and commands found in args. The first error is returned.
+//
+// Flags are parsed again each time a sub command is selected, with the flags of all selected commands, as kingpin
+// flags are inherited by sub commands.
+func (p *ParseContext) parse(args []string) error {
+	cur := p.app.root
+	for {
+		fs := p.newFlagSet(cur)
+		err := fs.Parse(args)
+		words := fs.Args()
+		args_only := fs.ArgsLenAtDash()
+		if args_only < 0 {
+			args_only = len(words)
+		}
+
+		depth := len(p.cmds)
+		if depth < args_only {
+			if sub := cur.getCommand(words[depth]); sub != nil {
+				cur = sub
+				p.cmds = append(p.cmds, cur)
+				continue
+			}
+		}
+		for i, word := range words[depth:] {
+			switch {
+			case i < len(cur.args):
+				p.args[cur.args[i]] = word
+			case err != nil:
+			case len(cur.cmds) > 0 && depth+i < args_only:
+				err = fmt.Errorf("expected command but got %q", word)
+			default:
+				err = fmt.Errorf("unexpected %s", word)
+			}
+		}
+		return err
+	}
+}
+
+// newFlagSet return a pflag FlagSet with flags of cur and its parents, collecting their values in the context.
+// Undeclared flags are ignored. Like cobra, a sub command flag hides a parent flag with the same name.
+func (p *ParseContext) newFlagSet(cur *CmdClause) *pflag.FlagSet {
+	p.flags = make(map[*FlagClause][]string)
+	fs := pflag.NewFlagSet(p.app.Name(), pflag.ContinueOnError)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	fs.SetOutput(ioutil.Discard)
+	for cmd := cur; cmd != nil; cmd = cmd.parent {
+		for _, f := range cmd.flags {
+			if fs.Lookup(f.name) != nil {
+				continue
+			}
+			short := f.short
+			if short != "" && fs.ShorthandLookup(short) != nil {
+				short = ""
+			}
+			flag := fs.VarPF(&contextValue{context: p, flag: f}, f.name, short, f.help)
+			if f.boolean {
+				flag.NoOptDefVal = "true"
+			}
+		}
+	}
+	return fs
+}
+
+// IsInvalidContext return true if the context is nil or if the command line parse has failed.
+func (p *ParseContext) IsInvalidContext() bool {
+	if p == nil || p.err != nil {
+		return true
+	}
+	return false
+}
+
+// GetArgValue get value from cli, or if missing, ENV or if missing, defaults
+func (p *ParseContext) GetArgValue(a clier.ArgClauser) (interface{}, bool) {
+	v, source := p.getArgValue(a)
+	return v, source != ""
+}
+
+// GetArgSource return the source of the arg value. (See clier.ParseContexter)
+func (p *ParseContext) GetArgSource(a clier.ArgClauser) string {
+	_, source := p.getArgValue(a)
+	return source
+}
+
+func (p *ParseContext) getArgValue(a clier.ArgClauser) (interface{}, string) {
+	argClause := a.(*ArgClause)
+	if v, found := p.args[argClause]; found {
+		return v, clier.SourceCli
+	}
+	if v, found := argClause.getEnvar(); found {
+		return v, clier.SourceEnvar
+	}
+	if argClause.hasDefaults() {
+		return argClause.getDefaults(), clier.SourceDefault
+	}
+	return nil, ""
+}
+
+// GetFlagValue get value from cli, or if missing, ENV or if missing, defaults
+//
+// Repeatable flags (Strings, StringMap) values are returned as []string. Their defaults are kept as *string.
+func (p *ParseContext) GetFlagValue(f clier.FlagClauser) (interface{}, bool) {
+	v, source := p.getFlagValue(f)
+	return v, source != ""
+}
+
+// GetFlagSource return the source of the flag value. (See clier.ParseContexter)
+func (p *ParseContext) GetFlagSource(f clier.FlagClauser) string {
+	_, source := p.getFlagValue(f)
+	return source
+}
+
+func (p *ParseContext) getFlagValue(f clier.FlagClauser) (interface{}, string) {
+	flagClause := f.(*FlagClause)
+	if values, found := p.flags[flagClause]; found {
+		if flagClause.isMulti() {
+			return append([]string{}, values...), clier.SourceCli
+		}
+		return values[len(values)-1], clier.SourceCli
+	}
+	if v, found := flagClause.getEnvar(); found {
+		if flagClause.isMulti() {
+			return clier.EnvarValues(v), clier.SourceEnvar
+		}
+		return v, clier.SourceEnvar
+	}
+	if flagClause.hasDefaults() {
+		return flagClause.getDefaults(), clier.SourceDefault
+	}
+	return nil, ""
+}
+
+func (p *ParseContext) SelectedCommands() (res []clier.CmdClauser) {
+	res = make([]clier.CmdClauser, 0, len(p.cmds))
+	for _, cmd := range p.cmds {
+		res = append(res, cmd)
+	}
+	return
+}
+
+func (p *ParseContext) GetParam(param_name string) (ret interface{}, err string) {
+
+	return
+}
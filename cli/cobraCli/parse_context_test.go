@@ -0,0 +1,166 @@
+package cobraCli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseContext_GetArgValue(t *testing.T) {
+	t.Log("Expect ParseContext_GetArgValue() to return the appropriate context value.")
+
+	const (
+		test       = "test"
+		test_help  = "test help"
+		test_value = "test value"
+		myapp      = "myapp"
+	)
+	// --- Setting test context ---
+	a := New(&cobra.Command{Use: test, Short: test_help}, myapp)
+	c1 := a.Command("add", "")
+	c2 := c1.Command("test", "")
+
+	a3 := c2.Arg("arg", test_help)
+	a3.String()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", test, test_value})
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected GetContext to not return an error. Got %s", err)
+		return
+	}
+
+	v, found := c.GetArgValue(a3)
+	if !found {
+		t.Errorf("Expected GetArgValue() to get '%s' arg. Not found", test)
+	}
+	if v != test_value {
+		t.Errorf("Expected GetArgValue() to get and return '%s'. But Got '%s'", test_value, v)
+	}
+	if cmds := c.SelectedCommands(); len(cmds) != 2 || cmds[1].FullCommand() != "add test" {
+		t.Errorf("Expected SelectedCommands() to return 'add' and 'add test'. Got %d commands", len(cmds))
+	}
+}
+
+func TestParseContext_GetFlagValue(t *testing.T) {
+	t.Log("Expect ParseContext_GetFlagValue() to return the appropriate context value.")
+
+	const (
+		test       = "test"
+		test_help  = "test help"
+		test_value = "test value"
+		myapp      = "myapp"
+	)
+	// --- Setting test context ---
+	a := New(&cobra.Command{Use: test, Short: test_help}, myapp)
+	c1 := a.Command("add", "")
+	c2 := c1.Command("test", "")
+
+	f3 := c2.Flag("flag", test_help)
+	f3.String()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", test, "--flag", test_value})
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected GetContext to not return an error. Got %s", err)
+		return
+	}
+
+	v, found := c.GetFlagValue(f3)
+	if !found {
+		t.Errorf("Expected GetFlagValue() to get '%s' arg. Not found", test)
+	}
+	if v != test_value {
+		t.Errorf("Expected GetFlagValue() to get and return '%s'. But Got '%s'", test_value, v)
+	}
+}
+
+func TestParseContext_UnknownFlags(t *testing.T) {
+	t.Log("Expect ParseContext() to ignore flags not declared yet.")
+
+	const test_value = "test value"
+	// --- Setting test context ---
+	a := New(&cobra.Command{Use: "test"}, "myapp")
+	c1 := a.Command("add", "")
+	c2 := c1.Command("apps", "")
+	f := c2.Flag("apps", "")
+	f.Strings()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"--later", "value", "add", "apps", "--infra-name=x", "--apps", test_value,
+		"--github-token", "secret", "--apps", "other"})
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected ParseContext() to not return an error. Got %s", err)
+		return
+	}
+	if cmds := c.SelectedCommands(); len(cmds) != 2 {
+		t.Errorf("Expected 2 selected commands. Got %d", len(cmds))
+	}
+	v, found := c.GetFlagValue(f)
+	if l, ok := v.([]string); !found || !ok || len(l) != 2 || l[0] != test_value || l[1] != "other" {
+		t.Errorf("Expected GetFlagValue() to return the 2 apps values. Got %#v", v)
+	}
+
+	// --- Run the test ---
+	_, err = a.Parse([]string{"add", "apps", "--apps", test_value, "--github-token", "secret"})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail on an undeclared flag. Got no error.")
+	}
+}
+
+func TestParseContext_ShortFlags(t *testing.T) {
+	t.Log("Expect ParseContext() to parse combined short flags like pflag.")
+
+	// --- Setting test context ---
+	a := New(&cobra.Command{Use: "test"}, "myapp")
+	c1 := a.Command("add", "")
+	debug := c1.Flag("debug", "").Short('d')
+	debug.Bool()
+	verbose := c1.Flag("verbose", "").Short('v')
+	verbose.Bool()
+	title := c1.Flag("title", "").Short('t')
+	title.String()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", "-dv", "-x", "-tmy title"})
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected ParseContext() to not return an error. Got %s", err)
+		return
+	}
+	if v, found := c.GetFlagValue(debug); !found || v != "true" {
+		t.Errorf("Expected debug to be 'true'. Got '%v' (%t)", v, found)
+	}
+	if v, found := c.GetFlagValue(verbose); !found || v != "true" {
+		t.Errorf("Expected verbose to be 'true'. Got '%v' (%t)", v, found)
+	}
+	if v, found := c.GetFlagValue(title); !found || v != "my title" {
+		t.Errorf("Expected title to be 'my title'. Got '%v' (%t)", v, found)
+	}
+}
+
+func TestParseContext_InvalidContext(t *testing.T) {
+	t.Log("Expect ParseContext() to return an invalid context when the parse fails.")
+
+	// --- Setting test context ---
+	a := New(&cobra.Command{Use: "test"}, "myapp")
+	a.Command("add", "").Command("apps", "")
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", "unknown"})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected ParseContext() to fail. Got no error.")
+	}
+	if c == nil || !c.IsInvalidContext() {
+		t.Error("Expected the context to be invalid.")
+	}
+}
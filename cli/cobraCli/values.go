@@ -0,0 +1,78 @@
+package cobraCli
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// urlValue is a pflag.Value of an URL, like kingpin URL().
+type urlValue struct {
+	u **url.URL
+}
+
+func (v *urlValue) Set(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %s", err)
+	}
+	*v.u = u
+	return nil
+}
+
+func (v *urlValue) String() string {
+	if v.u == nil || *v.u == nil {
+		return ""
+	}
+	return (*v.u).String()
+}
+
+func (v *urlValue) Type() string {
+	return "url"
+}
+
+// contextValue is a pflag.Value collecting a flag values in a ParseContext. (See ParseContext.newFlagSet)
+type contextValue struct {
+	context *ParseContext
+	flag    *FlagClause
+}
+
+func (v *contextValue) Set(value string) error {
+	v.context.flags[v.flag] = append(v.context.flags[v.flag], value)
+	return nil
+}
+
+func (v *contextValue) String() string {
+	return ""
+}
+
+func (v *contextValue) Type() string {
+	if v.flag.boolean {
+		return "bool"
+	}
+	return "string"
+}
+
+// valuerValue is a pflag.Value of a clier.Valuer. (See SetValue)
+type valuerValue struct {
+	clier.Valuer
+}
+
+func (v *valuerValue) Type() string {
+	return "value"
+}
+
+// checkEnum return an error if value is not one of options. An empty value is accepted.
+func checkEnum(options []string, value, what string) error {
+	if len(options) == 0 || value == "" {
+		return nil
+	}
+	for _, option := range options {
+		if value == option {
+			return nil
+		}
+	}
+	return fmt.Errorf("enum value must be one of %s, got '%s' for %s", strings.Join(options, ","), value, what)
+}
@@ -14,7 +14,7 @@ func (c *ForjCli)loadAppData() {
 	for name, flag := range c.flags {
 		v, _ := flag.GetContextValue(c.cli_context.context)
 
-		obj_data.set(flag.Type(), name, v)
+		obj_data.setFrom(flag.Type(), name, v, paramOrigin(c.cli_context.context, flag, v))
 	}
 
 	if c.cli_context.action == nil {
@@ -26,7 +26,7 @@ func (c *ForjCli)loadAppData() {
 		}
 		v, _ := param.GetContextValue(c.cli_context.context)
 
-		obj_data.set(param.Type(), name, v)
+		obj_data.setFrom(param.Type(), name, v, paramOrigin(c.cli_context.context, param, v))
 	}
 }
 
@@ -3,6 +3,8 @@ package cli
 import (
 	"fmt"
 	"github.com/forj-oss/forjj-modules/trace"
+	"net/url"
+	"time"
 )
 
 // IsAppValueFound return true if the parameter value is found on App Layer
@@ -113,7 +115,7 @@ func (c *ForjCli) GetAppStringValue(paramValue string) (string, error) {
 	if c.cli_context.context == nil {
 		return "", fmt.Errorf("Unable to find '%s' parameter from Application layer context. Context nil.", paramValue)
 	}
-	if v, found := c.cli_context.context.GetFlagValue(f.flag); found {
+	if v, found := f.GetContextValue(c.cli_context.context); found {
 		return to_string(v), nil
 	}
 	// The parser has not found any flag. Means no value is set in the list of args. Not an error. Just no value.
@@ -243,6 +245,80 @@ func (c *ForjCli) GetStringValue(object, key, param_name string) (string, bool,
 	}
 }
 
+// GetIntValue : Get an Int of the parameter from cli.
+//
+// Get data from object defined.
+// if object == "application", it will get data from the Application layer
+func (c *ForjCli) GetIntValue(object, key, param_name string) (int, bool, error) {
+	if v, found, err := c.getValue(object, key, param_name); found {
+		return to_int(v), true, nil
+	} else {
+		return 0, false, err
+	}
+}
+
+// GetFloatValue : Get a Float of the parameter from cli.
+//
+// Get data from object defined.
+// if object == "application", it will get data from the Application layer
+func (c *ForjCli) GetFloatValue(object, key, param_name string) (float64, bool, error) {
+	if v, found, err := c.getValue(object, key, param_name); found {
+		return to_float(v), true, nil
+	} else {
+		return 0, false, err
+	}
+}
+
+// GetDurationValue : Get a Duration of the parameter from cli.
+//
+// Get data from object defined.
+// if object == "application", it will get data from the Application layer
+func (c *ForjCli) GetDurationValue(object, key, param_name string) (time.Duration, bool, error) {
+	if v, found, err := c.getValue(object, key, param_name); found {
+		return to_duration(v), true, nil
+	} else {
+		return 0, false, err
+	}
+}
+
+// GetURLValue : Get an URL of the parameter from cli.
+//
+// Get data from object defined.
+// if object == "application", it will get data from the Application layer
+//
+// Enum and Path parameters are strings. Use GetStringValue to get them.
+func (c *ForjCli) GetURLValue(object, key, param_name string) (*url.URL, bool, error) {
+	if v, found, err := c.getValue(object, key, param_name); found {
+		return to_url(v), true, nil
+	} else {
+		return nil, false, err
+	}
+}
+
+// GetStringSliceValue : Get a []string of a repeatable parameter from cli.
+//
+// Get data from object defined.
+// if object == "application", it will get data from the Application layer
+func (c *ForjCli) GetStringSliceValue(object, key, param_name string) ([]string, bool, error) {
+	if v, found, err := c.getValue(object, key, param_name); found {
+		return to_string_slice(v), true, nil
+	} else {
+		return nil, false, err
+	}
+}
+
+// GetStringMapValue : Get a map[string]string of a repeatable key=value parameter from cli.
+//
+// Get data from object defined.
+// if object == "application", it will get data from the Application layer
+func (c *ForjCli) GetStringMapValue(object, key, param_name string) (map[string]string, bool, error) {
+	if v, found, err := c.getValue(object, key, param_name); found {
+		return to_string_map(v), true, nil
+	} else {
+		return nil, false, err
+	}
+}
+
 // IsObjectList returns
 // - true if the context is a list and is that object.
 // - true if the action has a ObjectList
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -22,8 +23,33 @@ type ForjCli struct {
 	err          error                                     // Last error found.
 	bef_ctx_hook func(*ForjCli, interface{}) (error, bool) // Last parse hook applied on cli.
 	aft_ctx_hook func(*ForjCli, interface{}) (error, bool) // Last parse hook applied on cli.
+	bef_hook     HookFunc                                  // Parse hook executed before objects hooks. See OnBeforeParse.
+	aft_hook     HookFunc                                  // Parse hook executed after objects hooks. See OnAfterParse.
+	hook_logger  HookLogger                                // Logger given to hooks. gotrace if nil.
+	hook_pass    int                                       // Current context resolution pass.
+	hook_changed bool                                      // A hook has called HookContext.Changed in the current pass.
+	legacy_hooks map[string]bool                           // Legacy hooks executed by the current context resolution.
+	parse_ctx    context.Context                           // Context given to ParseWithContext.
 	parse        bool                                      // true is parse task is done.
-	cur_cmds     []clier.CmdClauser
+	// Plugin field conflicts management
+	conflict_policy string               // How plugin field conflicts are resolved. (ConflictFirstWins by default)
+	conflicts       []*ForjFieldConflict // Collection of plugin field conflicts detected.
+	// Configuration files management
+	config_files []string           // Configuration files, by precedence order. (last wins)
+	config       []*forjConfigLayer // Configuration files loaded at parse time.
+	// Automatic environment variables management
+	envar_prefix     string                // Set by AutoEnvar. Empty if disabled.
+	envars           map[string]string     // Params identification per environment variable.
+	envar_collisions []*ForjEnvarCollision // Collection of params using the same environment variable.
+	// Context resolution
+	context_max_passes int               // Maximum number of context resolution passes. See ContextMaxPasses.
+	context_passes     []ForjContextPass // Context resolution passes executed by the last parse.
+	// Interactive mode
+	prompter ForjPrompter      // Ask missing values. nil if the interactive mode is disabled.
+	prompted map[string]string // Values asked by the current parse. (<object>/<instance>/<field>)
+	// Secrets management
+	secret_provider SecretProvider // Consulted for secret fields without value. nil if not set.
+	cur_cmds        []clier.CmdClauser
 
 	sel_actions map[string]*ForjAction // Selected actions
 	sel_object  *ForjObject            // Selected Object
@@ -43,6 +69,7 @@ func (c *ForjCli) ParseBeforeHook(context_hook func(*ForjCli, interface{}) (erro
 		return nil
 	}
 	c.bef_ctx_hook = context_hook
+	c.bef_hook = nil
 	return c
 }
 
@@ -52,6 +79,7 @@ func (c *ForjCli) ParseAfterHook(context_hook func(*ForjCli, interface{}) (error
 		return nil
 	}
 	c.aft_ctx_hook = context_hook
+	c.aft_hook = nil
 	return c
 }
 
@@ -63,20 +91,46 @@ func (c *ForjCli) IsParsePhase() bool {
 }
 
 // Parse do the parse of the command line
-func (c *ForjCli) Parse(args []string, context interface{}) (cmd string, err error) {
+func (c *ForjCli) Parse(args []string, data interface{}) (cmd string, err error) {
+	return c.ParseWithContext(context.Background(), args, data)
+}
+
+// ParseWithContext do the parse of the command line. ctx is given to parse hooks. See HookContext.
+//
+// Parse fails if ctx is cancelled before a hook is started.
+func (c *ForjCli) ParseWithContext(ctx context.Context, args []string, data interface{}) (cmd string, err error) {
 	c.parse = false
-	err = c.loadContext(args, context)
+	c.parse_ctx = ctx
+	c.prompted = nil
+	defer func() { c.parse_ctx = nil }()
+	if err = c.loadConfigFiles(); err != nil {
+		return
+	}
+	err = c.loadContext(args, data)
 	if err != nil {
+		err = c.suggestError(err, args)
+		return
+	}
+
+	// Configuration files values are set on objects instances not set from the cli or envar.
+	if err = c.applyConfigValues(); err != nil {
 		return
 	}
 
 	// Load all object extra flags/arg data
 	c.parse = true
 	if cmd, err = c.App.Parse(args); err != nil {
+		err = c.suggestError(err, args)
+		return
+	}
+	if err = c.resolveSecretParams(); err != nil {
 		return
 	}
 
-	err = c.loadObjectData()
+	if err = c.loadObjectData(); err != nil {
+		return
+	}
+	err = c.loadProviderSecrets()
 	return
 }
 
@@ -95,12 +149,12 @@ func (c *ForjCli) GetCurrentCommand() []clier.CmdClauser {
 func (c *ForjCli) String() (ret string) {
 	ret = fmt.Sprintf("Applicationer: %p\n", c.App)
 	ret += fmt.Sprintf("context : %s\n", c.cli_context)
-	if c.bef_ctx_hook == nil {
+	if c.beforeHook() == nil {
 		ret += fmt.Sprintf("Before Hook : %t\n", false)
 	} else {
 		ret += fmt.Sprintf("Before Hook : %t\n", true)
 	}
-	if c.aft_ctx_hook == nil {
+	if c.afterHook() == nil {
 		ret += fmt.Sprintf("After Hook : %t\n", false)
 	} else {
 		ret += fmt.Sprintf("After Hook : %t\n", true)
@@ -247,6 +301,24 @@ const (
 	String = "string"
 	// Bool - Define the Param data type to bool.
 	Bool = "bool"
+	// Int - Define the Param data type to int.
+	Int = "int"
+	// Float - Define the Param data type to float64.
+	Float = "float"
+	// Duration - Define the Param data type to time.Duration. (ex: 1h30m)
+	Duration = "duration"
+	// Enum - Define the Param data type to a string limited to values given by ForjOpts.Enum().
+	Enum = "enum"
+	// URL - Define the Param data type to an URL string. Use GetURLValue to get it as *url.URL.
+	URL = "url"
+	// Path - Define the Param data type to a file path string. The path is cleaned. (filepath.Clean)
+	Path = "path"
+	// StringSlice - Define a repeatable flag data type to []string. (--label a --label b)
+	// Default values are comma separated. Environment variable values are newline separated, like kingpin.
+	StringSlice = "string-slice"
+	// StringMap - Define a repeatable flag data type to map[string]string. (--env KEY=VAL --env K2=V2)
+	// Default values are comma separated. Environment variable values are newline separated, like kingpin.
+	StringMap = "string-map"
 	// List - Define a ForjObjectList data type.
 	List = "list"
 )
@@ -266,6 +338,7 @@ func NewForjCli(app clier.Applicationer) (c *ForjCli) {
 	c.list = make(map[string]*ForjObjectList)
 	c.filters = make(map[string]string)
 	c.sel_actions = make(map[string]*ForjAction)
+	c.conflict_policy = ConflictFirstWins
 	c.App = app
 	return
 }
@@ -296,14 +369,9 @@ func (c *ForjCli) AddAppFlag(paramIntType, name, help string, options *ForjOpts)
 	f.name = name
 	f.help = help
 	f.value_type = paramIntType
+	f.flagv = flagValue(f.flag, paramIntType, options)
+	f.options = options
 	f.set_options(options)
-
-	switch paramIntType {
-	case String:
-		f.flagv = f.flag.String()
-	case Bool:
-		f.flagv = f.flag.Bool()
-	}
 	c.flags[name] = f
 }
 
@@ -314,12 +382,25 @@ func (c *ForjCli) buildCapture(selector string) string {
 	return strings.Replace(selector, "##", "#", -1)
 }
 
+// list_capture_ref detects list captures references (#<key>) in a regexp.
+var list_capture_ref = regexp.MustCompile(`#(\w+)`)
+
+// unresolvedCaptures return the list captures referenced by selector which are not defined. See AddFieldListCapture.
+func (c *ForjCli) unresolvedCaptures(selector string) (ret []string) {
+	for _, match := range list_capture_ref.FindAllStringSubmatch(strings.Replace(selector, "##", "", -1), -1) {
+		if _, found := c.filters[match[1]]; !found {
+			ret = append(ret, match[1])
+		}
+	}
+	return
+}
+
 // getValue : Core get value code for GetBoolValue and GetStringValue
 func (c *ForjCli) getValue(object, key, param_name string) (interface{}, bool, error) {
 	var value *ForjRecords
 
 	if v, found := c.values[object]; !found {
-		return nil, false, fmt.Errorf("Unable to find Object '%s'", object)
+		return nil, false, &ForjUnknownObjectError{Object: object}
 	} else {
 		value = v
 	}
@@ -327,6 +408,13 @@ func (c *ForjCli) getValue(object, key, param_name string) (interface{}, bool, e
 	if v, found, err := value.Get(key, param_name); found {
 		return v, true, nil
 	} else {
+		if _, found := value.records[key]; !found && key != "" {
+			instances := make([]string, 0, len(value.records))
+			for name := range value.records {
+				instances = append(instances, name)
+			}
+			err = withHints(err, key, suggestWords(key, instances))
+		}
 		return nil, false, err
 	}
 }
@@ -355,7 +443,8 @@ func newForjObjectAction(ar *ForjAction, obj *ForjObject, name, desc string) *Fo
 	a := new(ForjObjectAction)
 	a.action = ar
 	a.name = ar.name + "_" + name
-	a.cmd = ar.cmd.Command(name, fmt.Sprintf(ar.help, desc))
+	a.help = fmt.Sprintf(ar.help, desc)
+	a.cmd = ar.cmd.Command(name, a.help)
 	a.params = make(map[string]ForjParam)
 	a.plugins = make([]string, 0, 5)
 	a.obj = obj
@@ -370,7 +459,11 @@ func (c *ForjCli) getObject(obj_name string) (*ForjObject, error) {
 	if v, found := c.objects[obj_name]; found {
 		return v, nil
 	}
-	return nil, fmt.Errorf("Unable to find object '%s'", obj_name)
+	objects := make([]string, 0, len(c.objects))
+	for name := range c.objects {
+		objects = append(objects, name)
+	}
+	return nil, withHints(&ForjUnknownObjectError{Object: obj_name}, obj_name, suggestWords(obj_name, objects))
 }
 
 func (c *ForjCli) getObjectAction(obj_name, action string) (o *ForjObject, a *ForjObjectAction, err error) {
@@ -382,7 +475,12 @@ func (c *ForjCli) getObjectAction(obj_name, action string) (o *ForjObject, a *Fo
 	}
 
 	if v, found := o.actions[action]; !found {
-		return nil, nil, fmt.Errorf("Unable to find action '%s' from object '%s'", action, obj_name)
+		actions := make([]string, 0, len(o.actions))
+		for name := range o.actions {
+			actions = append(actions, name)
+		}
+		return nil, nil, withHints(&ForjUnknownActionError{Action: action, Object: obj_name}, action,
+			suggestWords(action, actions))
 	} else {
 		a = v
 	}
@@ -395,14 +493,14 @@ func (c *ForjCli) getObjectListAction(list_name, action string) (o *ForjObject,
 	}
 	err = nil
 	if v, found := c.list[list_name]; !found {
-		return nil, nil, nil, fmt.Errorf("Unable to find object list '%s'", list_name)
+		return nil, nil, nil, &ForjUnknownObjectError{List: list_name}
 	} else {
 		l = v
 		o = l.obj
 	}
 
 	if v, found := o.actions[action]; !found {
-		return nil, nil, nil, fmt.Errorf("Unable to find action '%s' from object '%s'", action, list_name)
+		return nil, nil, nil, &ForjUnknownActionError{Action: action, Object: list_name}
 	} else {
 		a = v
 	}
@@ -412,7 +510,11 @@ func (c *ForjCli) getObjectListAction(list_name, action string) (o *ForjObject,
 func (c *ForjCli) getAction(action string) (a *ForjAction, err error) {
 	err = nil
 	if v, found := c.actions[action]; !found {
-		return nil, fmt.Errorf("Unable to find action '%s'", action)
+		actions := make([]string, 0, len(c.actions))
+		for name := range c.actions {
+			actions = append(actions, name)
+		}
+		return nil, withHints(&ForjUnknownActionError{Action: action}, action, suggestWords(action, actions))
 	} else {
 		a = v
 	}
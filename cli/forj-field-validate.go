@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ForjFieldValidationError is returned when a value bound to an object field do not respect the field regexp.
+type ForjFieldValidationError struct {
+	Object   string // Object name
+	Instance string // Object instance name (record key)
+	Field    string // Field name
+	Type     string // Field type
+	Value    string // Offending value. SecretMask for a secret.
+	Source   string // Where the value comes from. (cli, envar '<name>', default, list '<name>')
+	Regexp   string // Field regexp to respect
+	Reason   string // Set if the value is not valid for the field type.
+}
+
+func (e *ForjFieldValidationError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("Invalid %s value '%s' for field '%s' of object '%s' instance '%s' (from %s). %s",
+			e.Type, e.Value, e.Field, e.Object, e.Instance, e.Source, e.Reason)
+	}
+	return fmt.Sprintf("Invalid value '%s' for field '%s' of object '%s' instance '%s' (from %s). "+
+		"It must respect regular expression '%s'.", e.Value, e.Field, e.Object, e.Instance, e.Source, e.Regexp)
+}
+
+// compiledRegexp return the field regexp compiled to match a full value.
+//
+// Field list captures (#<key>) are replaced by their regexp. See AddFieldListCapture.
+// It returns nil if a capture is not defined. This error is reported when the field is declared.
+func (f *ForjField) compiledRegexp() (*regexp.Regexp, error) {
+	re := f.regexp
+	if re == "" {
+		re = ".*"
+	}
+	if f.obj != nil && f.obj.cli != nil {
+		if len(f.obj.cli.unresolvedCaptures(re)) > 0 {
+			return nil, nil
+		}
+		re = f.obj.cli.buildCapture(re)
+	}
+	re = "^(?:" + re + ")$"
+	if f.re != nil && f.re.String() == re {
+		return f.re, nil
+	}
+	r, err := regexp.Compile(re)
+	if err != nil {
+		return nil, fmt.Errorf("Field '%s' regexp '%s' is invalid. %s", f.name, f.regexp, err)
+	}
+	f.re = r
+	return r, nil
+}
+
+// checkValue validate a value bound to this field against the field type and regexp.
+//
+// nil and empty values are not checked. Non string values (bool) are checked with their string representation.
+// Other typed values (int, float, duration) are already converted and not checked.
+// Each value of a repeatable param (StringSlice, StringMap) is checked.
+func (f *ForjField) checkValue(instance string, value interface{}, source string) error {
+	if f == nil || value == nil {
+		return nil
+	}
+	if f.value_type == StringSlice || f.value_type == StringMap {
+		if values, ok := multiValues(value); ok {
+			for _, v := range values {
+				if err := f.checkString(instance, v, source); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	var str string
+	switch v := value.(type) {
+	case string, *string:
+		str = to_string(v)
+	case bool:
+		str = fmt.Sprintf("%t", v)
+	case *bool:
+		str = fmt.Sprintf("%t", *v)
+	default:
+		return nil
+	}
+	return f.checkString(instance, str, source)
+}
+
+// checkString validate one string value against the field type and regexp. Empty values are not checked.
+//
+// A secret reference is not checked. The secret is checked once read, after the parse. (See resolveSecretParams)
+func (f *ForjField) checkString(instance, str, source string) error {
+	if str == "" || (f.isSecret() && isSecretRef(str)) {
+		return nil
+	}
+
+	object := ""
+	if f.obj != nil {
+		object = f.obj.name
+	}
+	verr := &ForjFieldValidationError{
+		Object:   object,
+		Instance: instance,
+		Field:    f.name,
+		Type:     f.value_type,
+		Value:    str,
+		Source:   source,
+		Regexp:   f.regexp,
+	}
+	if f.isSecret() {
+		verr.Value = SecretMask
+	}
+	if err := checkType(f.value_type, str, f.options.GetEnum()); err != nil {
+		verr.Reason = err.Error()
+		return verr
+	}
+
+	re, err := f.compiledRegexp()
+	if err != nil {
+		return err
+	}
+	if re == nil || re.MatchString(str) {
+		return nil
+	}
+	return verr
+}
+
+// checkSingleObjectsValues validate single objects values. Those are set from defaults or envar at declaration time.
+//
+// All invalid values are reported.
+func (c *ForjCli) checkSingleObjectsValues() error {
+	var errs ForjErrors
+	for _, o := range c.objects {
+		if !o.single {
+			continue
+		}
+		r, found := c.values[o.name]
+		if !found {
+			continue
+		}
+		data, found := r.records[o.name]
+		if !found {
+			continue
+		}
+		for field_name, field := range o.fields {
+			v := data.attrs[field_name]
+			origin, _ := data.GetOrigin(field_name)
+			errs.Add(field.checkValue(o.name, v, origin.String()))
+		}
+	}
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"os"
+	"testing"
+)
+
+func TestForjField_checkValue(t *testing.T) {
+	t.Log("Expect ForjField_checkValue() to validate values against the field regexp.")
+
+	// --- Setting test context ---
+	const (
+		test      = "test"
+		test_help = "test help"
+		flag      = "flag"
+		flag_help = "flag help"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AddFieldListCapture("w", w_f)
+	o := c.NewObject(test, test_help, "").
+		AddKey(String, flag, flag_help, "#w", nil).
+		AddField(Bool, "enabled", "enabled help", "true|false", nil)
+	if o == nil {
+		t.Errorf("Expected object declaration to work. %s", c.GetObject(test).Error())
+		return
+	}
+	field := o.fields[flag]
+
+	// --- Run the test ---
+	err := field.checkValue("inst", "my-value", "cli")
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected 'my-value' to be valid. Got %s", err)
+	}
+	if err = field.checkValue("inst", "", "cli"); err != nil {
+		t.Errorf("Expected empty value to not be checked. Got %s", err)
+	}
+	if err = o.fields["enabled"].checkValue("inst", true, "cli"); err != nil {
+		t.Errorf("Expected bool value to be valid. Got %s", err)
+	}
+
+	// --- Run the test ---
+	// A value only partially matching the regexp must be rejected.
+	err = field.checkValue("inst", "my value", "default")
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected 'my value' to be rejected. Got no error.")
+		return
+	}
+	verr, ok := err.(*ForjFieldValidationError)
+	if !ok {
+		t.Errorf("Expected a ForjFieldValidationError. Got %T", err)
+		return
+	}
+	if verr.Object != test || verr.Instance != "inst" || verr.Field != flag || verr.Value != "my value" ||
+		verr.Source != "default" || verr.Regexp != "#w" {
+		t.Errorf("Expected validation error details to be set. Got %#v", verr)
+	}
+}
+
+func TestForjCli_Parse_InvalidFlagValue(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to fail when a flag value do not respect the field regexp.")
+
+	// --- Setting test context ---
+	const (
+		c_test  = "test"
+		c_flag  = "flag"
+		c_flag2 = "flag2"
+		c_cmd   = "cmd:"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(c_test, "test help", "").
+		AddKey(String, c_flag, "flag help", "[a-z]+", nil).
+		AddField(String, c_flag2, "flag2 help", "[0-9]+", nil).
+		DefineActions(create).OnActions().
+		AddFlag(c_flag, Opts().Required()).
+		AddFlag(c_flag2, Opts().Default("not-a-number"))
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_test, c_flag, "value"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail on invalid default. Got no error.")
+		return
+	}
+	if verr, ok := err.(*ForjFieldValidationError); !ok {
+		t.Errorf("Expected a ForjFieldValidationError. Got '%s'", err)
+	} else if verr.Field != c_flag2 || verr.Source != "default" || verr.Instance != "value" {
+		t.Errorf("Expected error on field '%s' from default. Got %#v", c_flag2, verr)
+	}
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{c_cmd + create, c_cmd + c_test, c_flag, "value1", c_flag2, "12"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail on invalid key value. Got no error.")
+	} else if verr, ok := err.(*ForjFieldValidationError); !ok || verr.Field != c_flag || verr.Source != "cli" {
+		t.Errorf("Expected error on field '%s' from cli. Got '%s'", c_flag, err)
+	}
+}
+
+func TestForjCli_checkSingleObjectsValues(t *testing.T) {
+	t.Log("Expect ForjCli_checkSingleObjectsValues() to validate single object envar values.")
+
+	// --- Setting test context ---
+	const envar = "FORJJ_CLI_TEST_WORKSPACE_PATH"
+	os.Setenv(envar, "relative path")
+	defer os.Unsetenv(envar)
+
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.NewObject(workspace, "workspace help", "").Single().
+		AddField(String, "path", "path help", "/.*", Opts().Envar(envar))
+
+	// --- Run the test ---
+	err := c.checkSingleObjectsValues()
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected checkSingleObjectsValues() to fail. Got no error.")
+		return
+	}
+	if verr, ok := err.(*ForjFieldValidationError); !ok {
+		t.Errorf("Expected a ForjFieldValidationError. Got '%s'", err)
+	} else if verr.Source != "envar '"+envar+"'" || verr.Value != "relative path" {
+		t.Errorf("Expected error from envar '%s'. Got %#v", envar, verr)
+	}
+}
+
+func TestForjObject_AddField_UndefinedCapture(t *testing.T) {
+	t.Log("Expect fields using undefined list captures to be declared, with values not validated.")
+
+	// --- Setting test context ---
+	const (
+		test      = "test"
+		test_help = "test help"
+		flag      = "flag"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	// --- Run the test ---
+	o := c.NewObject(test, test_help, "").
+		AddKey(String, flag, "flag help", "#w", nil)
+
+	// --- Start testing ---
+	if o == nil {
+		t.Error("Expected the field to be declared anyway. Got nil.")
+		return
+	}
+	if captures := c.unresolvedCaptures(o.fields[flag].regexp); len(captures) != 1 || captures[0] != "w" {
+		t.Errorf("Expected '#w' to be an undefined capture. Got %v", captures)
+	}
+	if err := o.fields[flag].checkValue("inst", "flag value", "cli"); err != nil {
+		t.Errorf("Expected value to not be validated. Got %s", err)
+	}
+
+	// --- Run the test ---
+	c.AddFieldListCapture("w", w_f)
+
+	// --- Start testing ---
+	if err := o.fields[flag].checkValue("inst", "flag value", "cli"); err == nil {
+		t.Error("Expected value to be validated once the capture is defined. Got no error.")
+	}
+	if captures := c.unresolvedCaptures("##v[a-z]*"); len(captures) != 0 {
+		t.Errorf("Expected an escaped '##' to not be a capture. Got %v", captures)
+	}
+}
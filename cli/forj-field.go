@@ -1,6 +1,10 @@
 package cli
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 type ForjField struct {
 	name       string      // name
@@ -14,7 +18,8 @@ type ForjField struct {
 	plugins   []string             // List of plugins that use this flag.
 	inActions map[string]ForjParam // Collection of flags linked to Main actions. From
 	// AddActionFlagsFromObjectAction
-	regexp string // Regexp to validate input.
+	regexp string         // Regexp to validate input.
+	re     *regexp.Regexp // Compiled regexp, to validate values.
 }
 
 func (f *ForjField) String() string {
@@ -37,3 +42,30 @@ func NewField(o *ForjObject, pIntType, name, help, re string, opts *ForjOpts) *F
 		options:    opts,
 	}
 }
+
+// owner return the first plugin which declared the field. Empty if declared by the application.
+func (f *ForjField) owner() string {
+	if len(f.plugins) == 0 {
+		return ""
+	}
+	return f.plugins[0]
+}
+
+// ownersString return a readable list of field owners, used in error messages.
+func (f *ForjField) ownersString() string {
+	if len(f.plugins) == 0 {
+		return "the application"
+	}
+	return "plugin(s) '" + strings.Join(f.plugins, "', '") + "'"
+}
+
+// declaration return the current field definition, as declared by plugin.
+func (f *ForjField) declaration(plugin string) ForjFieldDeclaration {
+	return ForjFieldDeclaration{
+		Plugin:  plugin,
+		Type:    f.value_type,
+		Help:    f.help,
+		Regexp:  f.regexp,
+		Options: f.options.toMap(),
+	}
+}
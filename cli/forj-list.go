@@ -37,6 +37,9 @@ type ForjObjectList struct {
 	valid_handler   func(*ForjListData) error       // Handler to validate data collected and correct if needed.
 	flags_list      map[string]*ForjObjectListFlags // list of flags (refering to this objectlist) added to App/Action/ObjectAction
 	context_hook    func(*ForjObjectList, *ForjCli, interface{}) (error, bool)
+	hook            ListHookFunc                    // Parse hook set by OnParse.
+	hook_priority   int      // Parse hook priority. See HookPriority.
+	hook_after      []string // Objects which hooks must run before this list hook. See HookAfter.
 }
 
 type ForjObjectListFlags struct {
@@ -101,6 +104,7 @@ func (l *ForjObjectList) ParseHook(context_hook func(*ForjObjectList, *ForjCli,
 		return nil
 	}
 	l.context_hook = context_hook
+	l.hook = nil
 	return l
 }
 
@@ -2,6 +2,9 @@ package cli
 
 import (
 	"os"
+	"reflect"
+	"sort"
+	"github.com/forj-oss/forjj-modules/cli/clier"
 	"github.com/forj-oss/forjj-modules/cli/tools"
 )
 
@@ -34,6 +37,50 @@ func (o *ForjOpts) IsRequired() bool {
 	return false
 }
 
+// Prompt define a required value which is asked to the user when missing, in interactive mode.
+// (See ForjCli.Interactive) The value is checked by the cli instead of the parser.
+func (o *ForjOpts) Prompt() *ForjOpts {
+	o.opts["required"] = true
+	o.opts["prompt"] = true
+	return o
+}
+
+func (o *ForjOpts) NoPrompt() *ForjOpts {
+	delete(o.opts, "prompt")
+	return o
+}
+
+// IsPrompted return true if the value is asked to the user when missing.
+func (o *ForjOpts) IsPrompted() bool {
+	if o == nil {
+		return false
+	}
+	v, found := o.opts["prompt"]
+	return found && to_bool(v)
+}
+
+// Secret define a secret value. It is never displayed (See SecretMask) and never taken from a default value.
+// It can be read from a file ('@<file>'), the standard input ('@-') or any source added by AddSecretSource.
+// The secret is read after the parse: parse hooks get the reference.
+func (o *ForjOpts) Secret() *ForjOpts {
+	o.opts["secret"] = true
+	return o
+}
+
+func (o *ForjOpts) NoSecret() *ForjOpts {
+	delete(o.opts, "secret")
+	return o
+}
+
+// IsSecret return true if the value is a secret.
+func (o *ForjOpts) IsSecret() bool {
+	if o == nil {
+		return false
+	}
+	v, found := o.opts["secret"]
+	return found && to_bool(v)
+}
+
 func (o *ForjOpts) Default(v string) *ForjOpts {
 	o.opts["default"] = v
 	return o
@@ -54,6 +101,25 @@ func (o *ForjOpts) NoShort() *ForjOpts {
 	return o
 }
 
+func (o *ForjOpts) Hidden() *ForjOpts {
+	o.opts["hidden"] = true
+	return o
+}
+
+func (o *ForjOpts) NotHidden() *ForjOpts {
+	delete(o.opts, "hidden")
+	return o
+}
+
+// isHidden return true if the param is hidden.
+func (o *ForjOpts) isHidden() bool {
+	if o == nil {
+		return false
+	}
+	v, found := o.opts["hidden"]
+	return found && to_bool(v)
+}
+
 func (o *ForjOpts) Envar(v string) *ForjOpts {
 	o.opts["envar"] = v
 	return o
@@ -64,13 +130,88 @@ func (o *ForjOpts) NoEnvar() *ForjOpts {
 	return o
 }
 
+// Enum define the list of values accepted by an Enum param.
+func (o *ForjOpts) Enum(values ...string) *ForjOpts {
+	o.opts["enum"] = values
+	return o
+}
+
+func (o *ForjOpts) NoEnum() *ForjOpts {
+	delete(o.opts, "enum")
+	return o
+}
+
+// GetEnum return the list of values accepted by an Enum param. nil if not defined.
+func (o *ForjOpts) GetEnum() []string {
+	if o == nil {
+		return nil
+	}
+	if v, found := o.opts["enum"]; found {
+		return v.([]string)
+	}
+	return nil
+}
+
 func (o *ForjOpts) HasEnvar() (bool, string) {
+	if o == nil {
+		return false, ""
+	}
 	if v, found := o.opts["envar"]; found {
 		return true, v.(string)
 	}
 	return false, ""
 }
 
+// FileDefault set the default value loaded from a configuration file. It has precedence over Default.
+func (o *ForjOpts) FileDefault(v, file string) *ForjOpts {
+	o.opts["file-default"] = v
+	o.opts["default-file"] = file
+	return o
+}
+
+// NoFileDefault remove the default value loaded from a configuration file.
+func (o *ForjOpts) NoFileDefault() *ForjOpts {
+	delete(o.opts, "file-default")
+	delete(o.opts, "default-file")
+	return o
+}
+
+// declaredDefault return the default value declared with Default. Empty if none or for a secret.
+func (o *ForjOpts) declaredDefault() string {
+	if o == nil || o.IsSecret() {
+		return ""
+	}
+	return to_string(o.opts["default"])
+}
+
+// defaultOrigin return the origin of the default value, without envar. A config file default has precedence.
+// A secret has no default.
+func (o *ForjOpts) defaultOrigin() (origin ForjValueOrigin) {
+	if o == nil || o.IsSecret() {
+		return
+	}
+	if v, found := o.opts["file-default"]; found {
+		return ForjValueOrigin{Source: SourceFile, Name: to_string(o.opts["default-file"]), Raw: to_string(v)}
+	}
+	if v, found := o.opts["default"]; found && to_string(v) != "" {
+		return ForjValueOrigin{Source: SourceDefault, Raw: to_string(v)}
+	}
+	return
+}
+
+// defaultValue return the default string value and its origin.
+//
+// Precedence is envar > config file default > declared default.
+func (o *ForjOpts) defaultValue() (string, ForjValueOrigin) {
+	if found, v := o.HasEnvar(); found {
+		if s := os.Getenv(v); s != "" {
+			return s, ForjValueOrigin{Source: SourceEnvar, Name: v, Raw: s}
+		}
+	}
+	origin := o.defaultOrigin()
+	return origin.Raw, origin
+}
+
 // GetDefault return the default value from defined options.
 // Used to set single object attribute default value
 // It must return a pointer to a pType value type (*string, *bool, ...)
@@ -78,37 +219,68 @@ func (o *ForjOpts) GetDefault(pType string) (interface{}) {
 	if o == nil {
 		return nil
 	}
-	switch pType {
-	case String:
-		s := ""
-		if found, v := o.HasEnvar() ; found {
-			s = os.Getenv(v)
-		}
-		if s != "" {
-			return &s
+	s, origin := o.defaultValue()
+	if s == "" {
+		return nil
+	}
+	if (pType == StringSlice || pType == StringMap) && origin.Source == SourceEnvar {
+		v, err := toTypedValue(pType, clier.EnvarValues(s))
+		if err != nil {
+			return nil
 		}
-		if v, found2 := o.opts["default"] ; found2 && v != "" {
+		switch v := v.(type) {
+		case []string:
+			return &v
+		case map[string]string:
 			return &v
 		}
+		return nil
+	}
+	switch pType {
+	case String:
+		return &s
 	case Bool:
-		if found, v := o.HasEnvar() ; found {
-			s := os.Getenv(v)
-			if s != "" {
-				if v, err := tools.ToBoolWithAddr(&s) ; err == nil {
-					return v
-				}
-			}
+		if b, err := tools.ToBoolWithAddr(&s) ; err == nil {
+			return b
 		}
-		if v, found2 := o.opts["default"] ; found2 && v != "" {
-			if b, err := tools.ToBoolWithAddr(&v) ; err == nil {
-				return b
-			}
-			return nil
+	case Int, Float, Duration, Enum, URL, Path, StringSlice, StringMap:
+		if v, err := toTypedValue(pType, &s); err == nil {
+			return v
 		}
 	}
 	return nil
 }
 
+// diff return the list of option names which differ between 2 options set.
+func (o *ForjOpts) diff(other *ForjOpts) (diffs []string) {
+	mine := o.toMap()
+	others := other.toMap()
+	for key, value := range mine {
+		if v, found := others[key]; !found || !reflect.DeepEqual(v, value) {
+			diffs = append(diffs, key)
+		}
+	}
+	for key := range others {
+		if _, found := mine[key]; !found {
+			diffs = append(diffs, key)
+		}
+	}
+	sort.Strings(diffs)
+	return
+}
+
+// toMap return a copy of options. An empty map if options are nil.
+func (o *ForjOpts) toMap() (ret map[string]interface{}) {
+	ret = make(map[string]interface{})
+	if o == nil {
+		return
+	}
+	for key, value := range o.opts {
+		ret[key] = value
+	}
+	return
+}
+
 func (o *ForjOpts) MergeWith(fromOpts *ForjOpts) {
 	for k, opt := range fromOpts.opts {
 		o.opts[k] = opt
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestForjCli_Parse_StringSliceAndMapFlags(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to load repeatable flags as slices and maps.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd  = "cmd:"
+		c_repo = "repo"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	c.AddAppFlag(StringSlice, "tag", "tag help", nil)
+	c.NewActions(create, create_help, "", false)
+	c.OnActions(create).AddFlag(StringMap, "env", "env help", nil)
+	c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(StringSlice, "labels", "labels help", "[a-z]+", nil).
+		AddField(StringMap, "vars", "vars help", "", Opts().Default("k1=v1,k2=v2")).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("labels", nil).
+		AddFlag("vars", nil)
+	if err := c.GetObject(c_repo).Error(); err != nil {
+		t.Errorf("Expected object declaration to work. %s", err)
+		return
+	}
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo",
+		"labels", "bug", "labels", "feature"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if f := app.GetFlag(create, c_repo, "labels"); f == nil || f.GetType() != StringSlice {
+		t.Error("Expected kingpin flag 'labels' to be a string slice.")
+	}
+	if v, found, _ := c.GetStringSliceValue(c_repo, "myrepo", "labels"); !found ||
+		!reflect.DeepEqual(v, []string{"bug", "feature"}) {
+		t.Errorf("Expected labels to be 'bug, feature'. Got %s", v)
+	}
+	expected := map[string]string{"k1": "v1", "k2": "v2"}
+	if v, found, _ := c.GetStringMapValue(c_repo, "myrepo", "vars"); !found || !reflect.DeepEqual(v, expected) {
+		t.Errorf("Expected vars to be the default map. Got %s", v)
+	}
+	if v := c.GetObjectValues(c_repo)["myrepo"].GetStringSlice("labels"); len(v) != 2 {
+		t.Errorf("Expected ForjData.GetStringSlice() to return 2 labels. Got %s", v)
+	}
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo",
+		"labels", "bug", "labels", "Not valid"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail on invalid label. Got no error.")
+	} else if verr, ok := err.(*ForjFieldValidationError); !ok || verr.Value != "Not valid" {
+		t.Errorf("Expected validation error on 'Not valid' label. Got '%s'", err)
+	}
+}
+
+func TestForjCli_Parse_StringMapInstanceFlags(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to load repeatable per-instance list flags as maps.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd   = "cmd:"
+		c_repo  = "repo"
+		c_repos = "repos"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AddFieldListCapture("w", w_f)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	if c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(StringMap, "vars", "vars help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("vars", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create) == nil {
+		t.Errorf("Expected object declaration to work. %s", c.GetObject(c_repo).Error())
+		return
+	}
+	c.OnActions(update).AddActionFlagFromObjectListAction(c_repo, "to_create", create)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + update, c_repos, "repo1,repo2",
+		"repo1-vars", "k1=v1", "repo1-vars", "k2=v2"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	expected := map[string]string{"k1": "v1", "k2": "v2"}
+	if v, found, err := c.GetStringMapValue(c_repo, "repo1", "vars"); !found || !reflect.DeepEqual(v, expected) {
+		t.Errorf("Expected repo1 vars to be '%s'. Got '%s' (%s)", expected, v, err)
+	}
+	if v, _, _ := c.GetStringMapValue(c_repo, "repo2", "vars"); len(v) != 0 {
+		t.Errorf("Expected repo2 vars to be empty. Got '%s'", v)
+	}
+}
+
+func TestForjCli_Parse_StringSliceEnvar(t *testing.T) {
+	t.Log("Expect repeatable fields environment variables to be split on newlines, on single objects and on flags.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd        = "cmd:"
+		c_repo       = "repo"
+		single_envar = "FORJJ_CLI_TEST_SINGLE_LABELS"
+		flag_envar   = "FORJJ_CLI_TEST_REPO_LABELS"
+		envar_value  = "a,b\nc"
+		settings     = "settings"
+	)
+	os.Setenv(single_envar, envar_value)
+	os.Setenv(flag_envar, envar_value)
+	defer os.Unsetenv(single_envar)
+	defer os.Unsetenv(flag_envar)
+	expected := []string{"a,b", "c"}
+
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(settings, "settings help", "").Single().
+		AddField(StringSlice, "labels", "labels help", "", Opts().Envar(single_envar))
+	c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(StringSlice, "labels", "labels help", "", Opts().Envar(flag_envar)).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("labels", nil)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, found, _ := c.GetStringSliceValue(settings, settings, "labels"); !found || !reflect.DeepEqual(v, expected) {
+		t.Errorf("Expected single object labels to be %q. Got %q", expected, v)
+	}
+	if v, found, _ := c.GetStringSliceValue(c_repo, "myrepo", "labels"); !found || !reflect.DeepEqual(v, expected) {
+		t.Errorf("Expected flag labels to be %q. Got %q", expected, v)
+	}
+}
+
+func TestForjCli_Parse_StringSliceAndMapListFields(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to store slices and maps of fields captured from a list.")
+
+	// --- Setting test context ---
+	const c_cmd = "cmd:"
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "", false)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "([a-z]+)", nil).
+		AddField(StringSlice, "labels", "labels help", "([a-z]+)", nil).
+		AddField(StringMap, "vars", "vars help", "([a-z0-9]+=[a-z0-9]+)", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("labels", nil).
+		AddFlag("vars", nil).
+		CreateList("to_create", ",", "name:labels:vars", "repos help").
+		AddActions(create)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + "repos", "repos", "myrepo:bug:k1=v1"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v := c.values["repo"].records["myrepo"].attrs["labels"]; !reflect.DeepEqual(v, []string{"bug"}) {
+		t.Errorf("Expected labels to be stored as []string 'bug'. Got %T '%v'", v, v)
+	}
+	if v := c.values["repo"].records["myrepo"].attrs["vars"]; !reflect.DeepEqual(v, map[string]string{"k1": "v1"}) {
+		t.Errorf("Expected vars to be stored as map 'k1=v1'. Got %T '%v'", v, v)
+	}
+}
+
+func TestForjCli_LoadValuesFrom_StringSliceAndMap(t *testing.T) {
+	t.Log("Expect ForjCli_LoadValuesFrom() to load repeatable flags values.")
+
+	// --- Setting test context ---
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AddAppFlag(StringSlice, "tag", "tag help", nil)
+	c.AddAppFlag(StringMap, "env", "env help", nil)
+	context := app.NewContext()
+	for _, value := range [][2]string{{"tag", "bug"}, {"tag", "feature"}, {"env", "k1=v1"}, {"env", "k2=v2"}} {
+		if _, err := context.SetContextValue(value[0], value[1]); err != nil {
+			t.Errorf("Expected context to work. But fails. %s", err)
+			return
+		}
+	}
+
+	// --- Run the test ---
+	c.LoadValuesFrom(context)
+
+	// --- Start testing ---
+	if v := *c.flags["tag"].flagv.(*[]string); !reflect.DeepEqual(v, []string{"bug", "feature"}) {
+		t.Errorf("Expected tag to be 'bug, feature'. Got %s", v)
+	}
+	expected := map[string]string{"k1": "v1", "k2": "v2"}
+	if v := *c.flags["env"].flagv.(*map[string]string); !reflect.DeepEqual(v, expected) {
+		t.Errorf("Expected env to be 'k1=v1, k2=v2'. Got %s", v)
+	}
+}
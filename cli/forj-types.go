@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/cli/tools"
+)
+
+// flagValue create the kingpin flag value storage for the param type.
+func flagValue(flag clier.FlagClauser, paramIntType string, options *ForjOpts) interface{} {
+	switch paramIntType {
+	case String, Path:
+		return flag.String()
+	case Bool:
+		return flag.Bool()
+	case Int:
+		return flag.Int()
+	case Float:
+		return flag.Float64()
+	case Duration:
+		return flag.Duration()
+	case Enum:
+		return flag.Enum(options.GetEnum()...)
+	case URL:
+		return flag.URL()
+	case StringSlice:
+		return flag.Strings()
+	case StringMap:
+		return flag.StringMap()
+	}
+	return nil
+}
+
+// argValue create the kingpin arg value storage for the param type.
+//
+// Repeatable types (StringSlice, StringMap) are not supported on args and return nil.
+func argValue(arg clier.ArgClauser, paramIntType string, options *ForjOpts) interface{} {
+	switch paramIntType {
+	case String, Path:
+		return arg.String()
+	case Bool:
+		return arg.Bool()
+	case Int:
+		return arg.Int()
+	case Float:
+		return arg.Float64()
+	case Duration:
+		return arg.Duration()
+	case Enum:
+		return arg.Enum(options.GetEnum()...)
+	case URL:
+		return arg.URL()
+	}
+	return nil
+}
+
+// checkType check if a string value can be interpreted as the param type.
+//
+// enum is the list of accepted values for Enum. If empty, any value is accepted.
+//
+// The error message explains what the value must be.
+func checkType(paramIntType, value string, enum []string) error {
+	switch paramIntType {
+	case Bool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("It must be a boolean.")
+		}
+	case Int:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("It must be an integer.")
+		}
+	case Float:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("It must be a number.")
+		}
+	case Duration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("It must be a duration. (ex: 1h30m, 10s)")
+		}
+	case URL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return fmt.Errorf("It must be an URL. (ex: https://host/path)")
+		}
+	case Enum:
+		if len(enum) == 0 {
+			return nil
+		}
+		for _, v := range enum {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("It must be one of '%s'.", strings.Join(enum, "', '"))
+	case StringMap:
+		if !strings.Contains(value, "=") {
+			return fmt.Errorf("It must be a 'key=value' pair.")
+		}
+	}
+	return nil
+}
+
+// multiValues return the list of values given to a repeatable param.
+//
+// A string value (default) is split on ','. false is returned if value is not a list of strings.
+// Environment variable values must be split by clier.EnvarValues before.
+func multiValues(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case *[]string:
+		return *v, true
+	case string, *string:
+		if s := to_string(v); s != "" {
+			return strings.Split(s, ","), true
+		}
+		return []string{}, true
+	}
+	return nil, false
+}
+
+// toTypedValue convert a value to the param type. Used to store typed values in ForjData.
+//
+// If value is a *string (default value), a pointer to the typed value is returned.
+// Enum, URL and Path values are kept as string (Path are cleaned).
+// StringSlice and StringMap values are built from a []string or a comma separated string.
+// An empty string is considered as not set and returns nil.
+func toTypedValue(paramIntType string, value interface{}) (interface{}, error) {
+	if value == nil || (is_string(value) && to_string(value) == "") {
+		return nil, nil
+	}
+	switch paramIntType {
+	case Int:
+		return tools.ToIntWithAddr(value)
+	case Float:
+		return tools.ToFloatWithAddr(value)
+	case Duration:
+		return tools.ToDurationWithAddr(value)
+	case Enum, URL, Path:
+		var str string
+		switch v := value.(type) {
+		case *url.URL:
+			str = v.String()
+		case string, *string:
+			str = to_string(v)
+		default:
+			return nil, fmt.Errorf("Unable to interpret '%v' as %s.", value, paramIntType)
+		}
+		if err := checkType(paramIntType, str, nil); err != nil {
+			return nil, fmt.Errorf("Unable to interpret '%s' as %s. %s", str, paramIntType, err)
+		}
+		if paramIntType == Path {
+			str = filepath.Clean(str)
+		}
+		if _, ok := value.(*string); ok {
+			return &str, nil
+		}
+		return str, nil
+	case StringSlice:
+		if _, ok := value.([]string); !ok && !is_string(value) {
+			return value, nil // Already typed. []string or *[]string
+		}
+		values, _ := multiValues(value)
+		ret := append([]string{}, values...)
+		if _, ok := value.(*string); ok {
+			return &ret, nil
+		}
+		return ret, nil
+	case StringMap:
+		values, ok := multiValues(value)
+		if !ok {
+			return value, nil // Already typed. map[string]string or *map[string]string
+		}
+		ret := make(map[string]string)
+		for _, entry := range values {
+			kv := strings.SplitN(entry, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("Unable to interpret '%s' as %s. It must be a 'key=value' pair.", entry, paramIntType)
+			}
+			ret[kv[0]] = kv[1]
+		}
+		if _, ok := value.(*string); ok {
+			return &ret, nil
+		}
+		return ret, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestForjCli_Parse_TypedFlags(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to load typed flags values.")
+
+	// --- Setting test context ---
+	const c_cmd = "cmd:"
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	c.NewActions(create, create_help, "", false)
+	c.NewObject("server", "server help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(Int, "port", "port help", "", nil).
+		AddField(Float, "ratio", "ratio help", "", nil).
+		AddField(Duration, "timeout", "timeout help", "", nil).
+		AddField(Enum, "format", "format help", "", Opts().Enum("json", "yaml")).
+		AddField(URL, "url", "url help", "", nil).
+		AddField(Path, "path", "path help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("port", nil).
+		AddFlag("ratio", nil).
+		AddFlag("timeout", Opts().Default("30s")).
+		AddFlag("format", nil).
+		AddFlag("url", nil).
+		AddFlag("path", nil)
+
+	if err := c.GetObject("server").Error(); err != nil {
+		t.Errorf("Expected object declaration to work. %s", err)
+		return
+	}
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + "server", "name", "srv",
+		"port", "8080", "ratio", "0.5", "format", "yaml", "url", "https://github.com/forj-oss",
+		"path", "/tmp//forjj/../data"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if f := app.GetFlag(create, "server", "port"); f == nil || f.GetType() != "int" {
+		t.Error("Expected kingpin flag 'port' to be an int.")
+	}
+	if f := app.GetFlag(create, "server", "format"); f == nil || !f.IsEnum("json", "yaml") {
+		t.Error("Expected kingpin flag 'format' to be an enum of 'json, yaml'.")
+	}
+	if v, found, err := c.GetIntValue("server", "srv", "port"); err != nil || !found || v != 8080 {
+		t.Errorf("Expected port to be 8080. Got %d (found: %t, err: %s)", v, found, err)
+	}
+	if v, found, _ := c.GetFloatValue("server", "srv", "ratio"); !found || v != 0.5 {
+		t.Errorf("Expected ratio to be 0.5. Got %f", v)
+	}
+	if v, found, _ := c.GetDurationValue("server", "srv", "timeout"); !found || v != 30*time.Second {
+		t.Errorf("Expected timeout to be the default 30s. Got %s", v)
+	}
+	if v, found, _ := c.GetURLValue("server", "srv", "url"); !found || v == nil || v.Host != "github.com" {
+		t.Errorf("Expected url host to be 'github.com'. Got %s", v)
+	}
+	if v, _, _, _ := c.GetStringValue("server", "srv", "path"); v != "/tmp/data" {
+		t.Errorf("Expected path to be cleaned to '/tmp/data'. Got '%s'", v)
+	}
+	if v, _, isDefault, _ := c.GetStringValue("server", "srv", "format"); v != "yaml" || isDefault {
+		t.Errorf("Expected format to be 'yaml' from cli. Got '%s' (default: %t)", v, isDefault)
+	}
+
+	data := c.GetObjectValues("server")["srv"]
+	if v := data.GetInt("port"); v != 8080 {
+		t.Errorf("Expected ForjData.GetInt() to return 8080. Got %d", v)
+	}
+	if v := data.GetDuration("timeout"); v != 30*time.Second {
+		t.Errorf("Expected ForjData.GetDuration() to return 30s. Got %s", v)
+	}
+}
+
+func TestForjCli_Parse_InvalidTypedFlags(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to reject values which are not valid for the param type.")
+
+	// --- Setting test context ---
+	const c_cmd = "cmd:"
+	tests := []struct {
+		flag, value string
+	}{
+		{"port", "80a"},
+		{"ratio", "half"},
+		{"timeout", "10"},
+		{"format", "xml"},
+		{"url", "github.com"},
+	}
+
+	for _, test := range tests {
+		c := NewForjCli(kingpinMock.New("Application"))
+
+		c.NewActions(create, create_help, "", false)
+		c.NewObject("server", "server help", "").
+			AddKey(String, "name", "name help", "", nil).
+			AddField(Int, "port", "port help", "", nil).
+			AddField(Float, "ratio", "ratio help", "", nil).
+			AddField(Duration, "timeout", "timeout help", "", nil).
+			AddField(Enum, "format", "format help", "", Opts().Enum("json", "yaml")).
+			AddField(URL, "url", "url help", "", nil).
+			AddField(Path, "path", "path help", "", nil).
+			DefineActions(create).OnActions().
+			AddFlag("name", Opts().Required()).
+			AddFlag("port", nil).
+			AddFlag("ratio", nil).
+			AddFlag("timeout", Opts().Default("30s")).
+			AddFlag("format", nil).
+			AddFlag("url", nil).
+			AddFlag("path", nil)
+
+		// --- Run the test ---
+		_, err := c.Parse([]string{c_cmd + create, c_cmd + "server", "name", "srv", test.flag, test.value}, nil)
+
+		// --- Start testing ---
+		if err == nil {
+			t.Errorf("Expected Parse() to fail on %s '%s'. Got no error.", test.flag, test.value)
+			continue
+		}
+		if verr, ok := err.(*ForjFieldValidationError); !ok {
+			t.Errorf("Expected a ForjFieldValidationError on %s. Got '%s'", test.flag, err)
+		} else if verr.Field != test.flag || verr.Reason == "" {
+			t.Errorf("Expected a type error on field '%s'. Got '%s'", test.flag, err)
+		}
+	}
+}
+
+func TestForjOpts_GetDefault_Typed(t *testing.T) {
+	t.Log("Expect ForjOpts_GetDefault() to return typed default addresses.")
+
+	// --- Run the test ---
+	v := Opts().Default("42").GetDefault(Int)
+
+	// --- Start testing ---
+	if i, ok := v.(*int); !ok || *i != 42 {
+		t.Errorf("Expected GetDefault(Int) to return *int 42. Got %#v", v)
+	}
+	if v = Opts().Default("1h").GetDefault(Duration); v == nil || *v.(*time.Duration) != time.Hour {
+		t.Errorf("Expected GetDefault(Duration) to return *time.Duration 1h. Got %#v", v)
+	}
+	if v = Opts().Default("abc").GetDefault(Int); v != nil {
+		t.Errorf("Expected GetDefault(Int) to return nil on invalid default. Got %#v", v)
+	}
+}
+
+func TestForjCli_Parse_TypedListFields(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to store typed values of fields captured from a list.")
+
+	// --- Setting test context ---
+	const c_cmd = "cmd:"
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "", false)
+	c.NewObject("server", "server help", "").
+		AddKey(String, "name", "name help", "([a-z]+)", nil).
+		AddField(Int, "port", "port help", "([0-9]+)", nil).
+		AddField(Float, "ratio", "ratio help", "([0-9.]+)", nil).
+		AddField(Duration, "timeout", "timeout help", "([0-9]+[smh])", nil).
+		AddField(URL, "url", "url help", "(https?://[a-z.]+)", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("port", nil).
+		AddFlag("ratio", nil).
+		AddFlag("timeout", nil).
+		AddFlag("url", nil).
+		CreateList("to_create", ",", "name:port:ratio:timeout:url", "servers help").
+		AddActions(create)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + "servers", "servers", "srv:8080:0.5:30s:http://host"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	tests := map[string]interface{}{
+		"port":    8080,
+		"ratio":   0.5,
+		"timeout": 30 * time.Second,
+		"url":     "http://host",
+	}
+	for field, expected := range tests {
+		if v := c.values["server"].records["srv"].attrs[field]; v != expected {
+			t.Errorf("Expected '%s' to be stored as %T '%v'. Got %T '%v'", field, expected, expected, v, v)
+		}
+	}
+}
+
+func TestForjCli_LoadValuesFrom_Typed(t *testing.T) {
+	t.Log("Expect ForjCli_LoadValuesFrom() to load typed flags values.")
+
+	// --- Setting test context ---
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AddAppFlag(Int, "port", "port help", nil)
+	c.AddAppFlag(Float, "ratio", "ratio help", nil)
+	c.AddAppFlag(Duration, "timeout", "timeout help", nil)
+	c.AddAppFlag(URL, "url", "url help", nil)
+	context := app.NewContext()
+	for flag, value := range map[string]string{"port": "8080", "ratio": "0.5", "timeout": "30s", "url": "http://host"} {
+		if _, err := context.SetContextValue(flag, value); err != nil {
+			t.Errorf("Expected context to work. But fails. %s", err)
+			return
+		}
+	}
+
+	// --- Run the test ---
+	c.LoadValuesFrom(context)
+
+	// --- Start testing ---
+	if v := *c.flags["port"].flagv.(*int); v != 8080 {
+		t.Errorf("Expected port to be 8080. Got %d", v)
+	}
+	if v := *c.flags["ratio"].flagv.(*float64); v != 0.5 {
+		t.Errorf("Expected ratio to be 0.5. Got %v", v)
+	}
+	if v := *c.flags["timeout"].flagv.(*time.Duration); v != 30*time.Second {
+		t.Errorf("Expected timeout to be 30s. Got %s", v)
+	}
+	if v := *c.flags["url"].flagv.(**url.URL); v == nil || v.String() != "http://host" {
+		t.Errorf("Expected url to be 'http://host'. Got '%v'", v)
+	}
+}
@@ -11,6 +11,7 @@ import (
 // ForjActionRef To define an action reference
 type ForjAction struct {
 	help          string                      // String which will 'printf' the object name as %s
+	desc          string                      // Action command help
 	name          string                      // Action Name
 	cmd           clier.CmdClauser            // Action used at action level
 	params        map[string]ForjParam        // Collection of Arguments/Flags
@@ -353,6 +354,7 @@ func (c *ForjCli) NewActions(name, act_help, compose_help string, for_forjj bool
 	}
 	r = new(ForjAction)
 	r.cmd = c.App.Command(name, act_help)
+	r.desc = act_help
 	r.help = compose_help
 	r.internal_only = for_forjj
 	r.params = make(map[string]ForjParam)
@@ -388,12 +390,17 @@ func (c *ForjCli) OnActions(actions ...string) *ForjCli {
 	return c
 }
 
-// setErr - Set an error flag to the cli and none exists.
+// setErr - Add an error to the cli error flag. (See setError)
 func (c *ForjCli) setErr(format string, a ...interface{}) {
-	if c.err != nil {
-		return
-	}
-	c.err = fmt.Errorf(format, a...)
+	c.setError(fmt.Errorf(format, a...))
+}
+
+// setError - Add a typed error to the cli error flag. Several errors are aggregated in a ForjErrors.
+func (c *ForjCli) setError(err error) {
+	errs := ForjErrors{}
+	errs.Add(c.err)
+	errs.Add(err)
+	c.err = errs.ErrorOrNil()
 }
 
 // cleanErr - Cleanup cli error flag.
@@ -414,11 +421,11 @@ func (c *ForjCli) WithObjectInstance(object_name, instance_name string) *ForjCli
 
 	o := c.GetObject(object_name)
 	if o == nil {
-		c.setErr("Object '%s' not found.", object_name)
+		c.setError(&ForjUnknownObjectError{Object: object_name})
 		return nil
 	}
 	if _, found := o.instances[instance_name]; !found {
-		o.setErr("Instance '%s' is not found.", instance_name)
+		o.setError(&ForjUnknownInstanceError{Object: object_name, Instance: instance_name})
 		return nil
 	}
 	o.sel_instance = instance_name
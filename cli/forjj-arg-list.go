@@ -225,6 +225,7 @@ func (f *ForjArgList) createObjectDataFromParams(params map[string]ForjParam) er
 		}
 		for key, attr := range list_data.Data {
 			data.attrs[key] = attr
+			data.origins[key] = ForjValueOrigin{Source: SourceCli, Name: f.name, Raw: attr}
 		}
 	}
 	return nil
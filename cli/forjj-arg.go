@@ -61,18 +61,13 @@ func (a *ForjArg) set_cmd(cmd clier.CmdClauser, paramIntType, name, help string,
 	}
 	a.set_options(options)
 
-	switch paramIntType {
-	case String:
-		a.argv = a.arg.String()
-	case Bool:
-		a.argv = a.arg.Bool()
-	}
+	a.argv = argValue(a.arg, paramIntType, a.options)
 	gotrace.Trace("kingping.Arg '%s' added to '%s'", name, cmd.FullCommand())
 }
 
 func (a *ForjArg) loadFrom(context clier.ParseContexter) {
-	if v, found := context.GetArgValue(a.arg); found {
-		copyValue(a.argv, v)
+	if v, found := a.GetContextValue(context); found {
+		copyValue(v, a.argv)
 		a.found = true
 	} else {
 		a.found = false
@@ -91,12 +86,15 @@ func (a *ForjArg) set_options(options *ForjOpts) {
 		return
 	}
 
-	if v, ok := options.opts["required"]; ok && to_bool(v) {
+	// A prompted value is checked by the cli. See ForjCli.Interactive
+	if v, ok := options.opts["required"]; ok && to_bool(v) && !options.IsPrompted() {
 		gotrace.Trace("set Arg %s as Required", a.name)
 		a.arg.Required()
 	}
 
-	if v, ok := options.opts["default"]; ok {
+	if v, ok := options.opts["default"]; ok && options.IsSecret() {
+		gotrace.Warning("Secret Arg %s default value ignored.", a.name)
+	} else if ok {
 		gotrace.Trace("set Arg %s default value to %s", a.name, to_string(v))
 		a.arg.Default(to_string(v))
 	}
@@ -134,10 +132,21 @@ func (a *ForjArg) GetStringAddr() *string {
 	return nil
 }
 
+// GetContextValue return the arg value found in the parse context.
+//
+// A secret reference is not resolved. Secrets are read once, after the parse. (See ForjOpts.Secret)
 func (a *ForjArg) GetContextValue(context clier.ParseContexter) (interface{}, bool) {
 	return context.GetArgValue(a.arg)
 }
 
+// getContextSource return the source of the arg value found in the parse context. (See clier.ParseContexter)
+func (a *ForjArg) getContextSource(context clier.ParseContexter) string {
+	if context == nil {
+		return ""
+	}
+	return context.GetArgSource(a.arg)
+}
+
 func (f *ForjArg) IsList() bool {
 	return false
 }
@@ -231,25 +240,20 @@ func (a *ForjArg) UpdateObject() error {
 }
 
 func (a *ForjArg) updateObject(c *ForjCli, object_name string) error {
-	var value interface{}
-
 	_, found, _, _ := c.GetStringValue(object_name, a.instance_name, a.field_name)
 
-	switch a.argv.(type) {
-	case *string:
-		value = *a.argv.(*string)
-		if value.(string) == "" && !found {
-			return nil
-		}
-	case *bool:
-		value = *a.argv.(*bool)
-		if !value.(bool) && !found {
-			return nil
-		}
-	default:
+	value, zero, ok := deref_value(a.argv)
+	if !ok {
 		return fmt.Errorf("Unable to convert flagv to object attribute value.")
 	}
-	return c.SetValue(object_name, a.instance_name, a.value_type, a.field_name, value)
+	if zero && !found {
+		return nil
+	}
+	if c.keepFileValue(object_name, a.instance_name, a.field_name, a) {
+		return nil
+	}
+	origin := c.updatedValueOrigin(object_name, a.instance_name, a.field_name, a, value)
+	return c.SetValueFrom(object_name, a.instance_name, a.value_type, a.field_name, value, origin)
 
 }
 
@@ -356,7 +360,7 @@ func (a *ForjArg) updateContextData() {
 	}
 	ctxt := a.obj.cli.cli_context.context
 	if v, found := a.GetContextValue(ctxt); found {
-		a.data.set(a.value_type, a.field_name, v)
+		a.data.setFrom(a.value_type, a.field_name, v, paramOrigin(ctxt, a, v))
 	}
 }
 
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/trace"
+	"github.com/kr/text"
+)
+
+// Plugin field conflict resolution policies.
+const (
+	// ConflictFirstWins : The first field declaration is kept. Next ones are reported and ignored.
+	ConflictFirstWins = "first-wins"
+	// ConflictStrict : Any conflicting field declaration fails the plugin loading.
+	ConflictStrict = "strict"
+	// ConflictMerge : Field options are merged with ForjOpts.MergeWith. Type, help and regexp are kept.
+	ConflictMerge = "merge"
+)
+
+// ForjFieldDeclaration is the field definition given by one plugin.
+type ForjFieldDeclaration struct {
+	Plugin  string
+	Type    string
+	Help    string
+	Regexp  string
+	Options map[string]interface{}
+}
+
+// ForjFieldConflict describes one field declared differently by several plugins.
+type ForjFieldConflict struct {
+	Object       string
+	Field        string
+	Declarations []ForjFieldDeclaration // First declaration is the owner one.
+	Differences  []string               // List of declaration elements in conflict. (type, help, regexp, <option>)
+	Resolution   string                 // Policy applied to this conflict.
+}
+
+func (fc *ForjFieldConflict) String() (ret string) {
+	ret = fmt.Sprintf("Field '%s' of object '%s' (%s): %s differ\n", fc.Field, fc.Object, fc.Resolution,
+		strings.Join(fc.Differences, ", "))
+	for _, decl := range fc.Declarations {
+		options := make([]string, 0, len(decl.Options))
+		for key, value := range decl.Options {
+			options = append(options, fmt.Sprintf("%s=%v", key, value))
+		}
+		sort.Strings(options)
+		ret += text.Indent(fmt.Sprintf("plugin '%s': type '%s', help '%s', regexp '%s', options [%s]\n",
+			decl.Plugin, decl.Type, decl.Help, decl.Regexp, strings.Join(options, ", ")), "  ")
+	}
+	return
+}
+
+// SetPluginConflictPolicy define how LoadPluginData resolves a field declared differently by several plugins.
+//
+// policy is one of ConflictFirstWins (default), ConflictStrict or ConflictMerge.
+func (c *ForjCli) SetPluginConflictPolicy(policy string) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	switch policy {
+	case ConflictFirstWins, ConflictStrict, ConflictMerge:
+		c.conflict_policy = policy
+	default:
+		c.setErr("Unknown plugin conflict policy '%s'.", policy)
+		return nil
+	}
+	return c
+}
+
+// GetPluginConflicts return the list of field conflicts detected while loading plugins data.
+func (c *ForjCli) GetPluginConflicts() []*ForjFieldConflict {
+	if c == nil {
+		return nil
+	}
+	return c.conflicts
+}
+
+// PluginConflictsReport return a human readable report of field conflicts detected between plugins.
+func (c *ForjCli) PluginConflictsReport() (ret string) {
+	if c == nil || len(c.conflicts) == 0 {
+		return
+	}
+	ret = fmt.Sprintf("%d plugin field conflict(s) found:\n", len(c.conflicts))
+	for _, conflict := range c.conflicts {
+		ret += text.Indent(conflict.String(), "  ")
+	}
+	return
+}
+
+// checkPluginField compare an existing field to a new plugin declaration and apply the conflict policy.
+//
+// It returns an error if the conflict cannot be resolved.
+func (c *ForjCli) checkPluginField(field *ForjField, plugin, pIntType, help, re string, opts *ForjOpts) error {
+	if re == "" {
+		re = ".*"
+	}
+	diffs := make([]string, 0, 4)
+	if field.value_type != pIntType {
+		diffs = append(diffs, "type")
+	}
+	if field.help != help {
+		diffs = append(diffs, "help")
+	}
+	if field.regexp != re {
+		diffs = append(diffs, "regexp")
+	}
+	diffs = append(diffs, field.options.diff(opts)...)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	conflict := &ForjFieldConflict{
+		Object:      field.obj.name,
+		Field:       field.name,
+		Differences: diffs,
+		Resolution:  c.conflict_policy,
+	}
+	conflict.Declarations = []ForjFieldDeclaration{
+		field.declaration(field.owner()),
+		{Plugin: plugin, Type: pIntType, Help: help, Regexp: re, Options: opts.toMap()},
+	}
+	c.conflicts = append(c.conflicts, conflict)
+
+	switch c.conflict_policy {
+	case ConflictStrict:
+		return fmt.Errorf("Field '%s' already declared by %s with a different %s.",
+			field.name, field.ownersString(), strings.Join(diffs, ", "))
+	case ConflictMerge:
+		if field.value_type != pIntType {
+			return fmt.Errorf("Unable to merge field '%s' declared as '%s' by %s. Plugin '%s' declares it as '%s'.",
+				field.name, field.value_type, field.ownersString(), plugin, pIntType)
+		}
+		if opts == nil {
+			return nil
+		}
+		if field.options == nil {
+			field.options = Opts()
+		}
+		field.options.MergeWith(opts)
+		field.obj.SetParamOptions(field.name, field.options)
+		gotrace.Trace("Field '%s' options merged with plugin '%s' declaration.", field.name, plugin)
+	default:
+		gotrace.Warning("Field '%s' declaration from plugin '%s' ignored. Already declared by %s. %s differs.",
+			field.name, plugin, field.ownersString(), strings.Join(diffs, ", "))
+	}
+	return nil
+}
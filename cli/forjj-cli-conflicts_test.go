@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+
+	"github.com/forj-oss/goforjj"
+)
+
+func TestForjCli_SetPluginConflictPolicy(t *testing.T) {
+	t.Log("Expect SetPluginConflictPolicy() to accept only known policies.")
+
+	// --- Setting test context ---
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	// --- Run the test ---
+	ret := c.SetPluginConflictPolicy("unknown")
+
+	// --- Start testing ---
+	if ret != nil {
+		t.Error("Expected SetPluginConflictPolicy() to fail. Got the cli object.")
+	}
+	if c.Error() == nil {
+		t.Error("Expected cli error to be set. Got none.")
+	}
+	if c.conflict_policy != ConflictFirstWins {
+		t.Errorf("Expected default policy to be '%s'. Got '%s'.", ConflictFirstWins, c.conflict_policy)
+	}
+}
+
+func TestForjCli_LoadPluginData_conflictFirstWins(t *testing.T) {
+	t.Log("Expect LoadPluginData() to keep the first declaration and report the conflict.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(update, update_help, "update %s", false)
+	c.SetPluginConflictPolicy(ConflictFirstWins)
+	github := &goforjj.YamlPluginComm{
+		Name: "github",
+		Objects: map[string]goforjj.YamlObject{
+			"repo": {
+				Actions:            []string{update},
+				Identified_by_flag: "name",
+				Flags: map[string]goforjj.YamlFlag{
+					"name":  {Help: "repo name"},
+					"title": {Help: "repo title", Options: goforjj.YamlFlagOptions{Default: "title1"}},
+				},
+			},
+		},
+	}
+	data := &goforjj.YamlPluginComm{
+		Name: "gitlab",
+		Objects: map[string]goforjj.YamlObject{
+			"repo": {
+				Actions:            []string{update},
+				Identified_by_flag: "name",
+				Flags: map[string]goforjj.YamlFlag{
+					"name":  {Help: "repo name"},
+					"title": {Help: "gitlab repo title", Options: goforjj.YamlFlagOptions{Default: "title2"}},
+				},
+			},
+		},
+	}
+	if err := c.LoadPluginData(github); err != nil {
+		t.Errorf("Expected first plugin to load. Got %s", err)
+		return
+	}
+
+	// --- Run the test ---
+	err := c.LoadPluginData(data)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected LoadPluginData() to succeed. Got %s", err)
+		return
+	}
+	conflicts := c.GetPluginConflicts()
+	if len(conflicts) != 1 {
+		t.Errorf("Expected 1 conflict. Got %d.", len(conflicts))
+		return
+	}
+	conflict := conflicts[0]
+	if conflict.Object != "repo" || conflict.Field != "title" {
+		t.Errorf("Expected conflict on 'repo/title'. Got '%s/%s'.", conflict.Object, conflict.Field)
+	}
+	if v := strings.Join(conflict.Differences, ","); v != "help,default" {
+		t.Errorf("Expected differences to be 'help,default'. Got '%s'.", v)
+	}
+	if len(conflict.Declarations) != 2 {
+		t.Errorf("Expected 2 declarations. Got %d.", len(conflict.Declarations))
+	} else {
+		if v := conflict.Declarations[0].Plugin; v != "github" {
+			t.Errorf("Expected first declaration from 'github'. Got '%s'.", v)
+		}
+		if v := conflict.Declarations[1].Options["default"]; v != "title2" {
+			t.Errorf("Expected second declaration default to be 'title2'. Got '%s'.", v)
+		}
+	}
+	if v := c.GetObject("repo").fields["title"].help; v != "repo title" {
+		t.Errorf("Expected field help to be kept. Got '%s'.", v)
+	}
+	if v := c.PluginConflictsReport(); !strings.Contains(v, "plugin 'gitlab'") {
+		t.Errorf("Expected report to list plugin 'gitlab'. Got '%s'.", v)
+	}
+}
+
+func TestForjCli_LoadPluginData_conflictStrict(t *testing.T) {
+	t.Log("Expect LoadPluginData() to fail on conflict in strict mode, with the owner name.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(update, update_help, "update %s", false)
+	c.SetPluginConflictPolicy(ConflictStrict)
+	github := &goforjj.YamlPluginComm{
+		Name: "github",
+		Objects: map[string]goforjj.YamlObject{
+			"repo": {
+				Actions:            []string{update},
+				Identified_by_flag: "name",
+				Flags: map[string]goforjj.YamlFlag{
+					"name":  {Help: "repo name"},
+					"title": {Help: "repo title", Options: goforjj.YamlFlagOptions{Default: "title1"}},
+				},
+			},
+		},
+	}
+	data := &goforjj.YamlPluginComm{
+		Name: "gitlab",
+		Objects: map[string]goforjj.YamlObject{
+			"repo": {
+				Actions:            []string{update},
+				Identified_by_flag: "name",
+				Flags: map[string]goforjj.YamlFlag{
+					"name":  {Help: "repo name"},
+					"title": {Help: "gitlab repo title", Options: goforjj.YamlFlagOptions{Default: "title2"}},
+				},
+			},
+		},
+	}
+	if err := c.LoadPluginData(github); err != nil {
+		t.Errorf("Expected first plugin to load. Got %s", err)
+		return
+	}
+
+	// --- Run the test ---
+	err := c.LoadPluginData(data)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected LoadPluginData() to fail. Got no error.")
+		return
+	}
+	if !strings.Contains(err.Error(), "'github'") {
+		t.Errorf("Expected error to give the field owner. Got '%s'.", err)
+	}
+}
+
+func TestForjCli_LoadPluginData_conflictMerge(t *testing.T) {
+	t.Log("Expect LoadPluginData() to merge options in merge mode.")
+
+	// --- Setting test context ---
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.NewActions(update, update_help, "update %s", false)
+	c.SetPluginConflictPolicy(ConflictMerge)
+	github := &goforjj.YamlPluginComm{
+		Name: "github",
+		Objects: map[string]goforjj.YamlObject{
+			"repo": {
+				Actions:            []string{update},
+				Identified_by_flag: "name",
+				Flags: map[string]goforjj.YamlFlag{
+					"name":  {Help: "repo name"},
+					"title": {Help: "repo title", Options: goforjj.YamlFlagOptions{Default: "title1"}},
+				},
+			},
+		},
+	}
+	data := &goforjj.YamlPluginComm{
+		Name: "gitlab",
+		Objects: map[string]goforjj.YamlObject{
+			"repo": {
+				Actions:            []string{update},
+				Identified_by_flag: "name",
+				Flags: map[string]goforjj.YamlFlag{
+					"name":  {Help: "repo name"},
+					"title": {Help: "gitlab repo title", Options: goforjj.YamlFlagOptions{Default: "title2"}},
+				},
+			},
+		},
+	}
+	if err := c.LoadPluginData(github); err != nil {
+		t.Errorf("Expected first plugin to load. Got %s", err)
+		return
+	}
+
+	// --- Run the test ---
+	err := c.LoadPluginData(data)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected LoadPluginData() to succeed. Got %s", err)
+		return
+	}
+	field := c.GetObject("repo").fields["title"]
+	if v := to_string(field.options.opts["default"]); v != "title2" {
+		t.Errorf("Expected merged default to be 'title2'. Got '%s'.", v)
+	}
+	if f := app.GetFlag(update, "repo", "title"); f == nil || !f.IsDefault("title2") {
+		t.Error("Expected kingpin flag default to be updated to 'title2'.")
+	}
+
+	// Type conflicts cannot be merged.
+	data.Name = "bitbucket"
+	flag := data.Objects["repo"].Flags["title"]
+	flag.Type = Bool
+	data.Objects["repo"].Flags["title"] = flag
+	if err := c.LoadPluginData(data); err == nil {
+		t.Error("Expected LoadPluginData() to fail on type conflict. Got no error.")
+	}
+}
@@ -1,8 +1,245 @@
 package cli
 
-import "github.com/forj-oss/goforjj"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/forj-oss/forjj-modules/trace"
+	"github.com/forj-oss/goforjj"
+)
 
 // LoadPluginData: Load Plugin Definition in cli.
+//
+// Each object declared by the plugin is created if missing, with the key identified by `identified_by_flag`
+// (or as a single object if no key is given), its fields and their flags/args on object actions.
+// Task flags are added to existing application actions.
+//
+// The plugin name is registered in any ForjField, ForjObjectAction and ForjFlag it contributes to.
+//
+// A field already declared differently is resolved with the policy set by SetPluginConflictPolicy.
 func (c *ForjCli) LoadPluginData(data *goforjj.YamlPluginComm) error {
+	if c == nil {
+		return fmt.Errorf("Invalid cli object. it is nil.")
+	}
+	if data == nil {
+		return fmt.Errorf("Unable to load plugin data. Got nil.")
+	}
+	if data.Name == "" {
+		return fmt.Errorf("Unable to load plugin data. Plugin name is empty.")
+	}
+
+	for _, obj_name := range sortedKeys(data.Objects) {
+		if err := c.loadPluginObject(data.Name, obj_name, data.Objects[obj_name]); err != nil {
+			return fmt.Errorf("Unable to load plugin '%s' object '%s'. %s", data.Name, obj_name, err)
+		}
+	}
+
+	for _, action_name := range sortedKeys(data.Tasks) {
+		if err := c.loadPluginTaskFlags(data.Name, action_name, data.Tasks[action_name]); err != nil {
+			return fmt.Errorf("Unable to load plugin '%s' task '%s' flags. %s", data.Name, action_name, err)
+		}
+	}
+	return nil
+}
+
+// loadPluginObject create or update one object from the plugin object definition.
+func (c *ForjCli) loadPluginObject(plugin, obj_name string, obj_def goforjj.YamlObject) error {
+	o, found := c.objects[obj_name]
+	if !found {
+		o = c.NewObject(obj_name, obj_def.Help, "")
+		if obj_def.Identified_by_flag == "" {
+			o.Single()
+		} else {
+			key_name := obj_def.Identified_by_flag
+			key_def, found := obj_def.Flags[key_name]
+			if !found {
+				delete(c.objects, obj_name)
+				return fmt.Errorf("Key '%s' is not declared in object flags.", key_name)
+			}
+			o.AddKey(pluginFieldType(key_def), key_name, key_def.Help, key_def.FormatRegexp, pluginFlagOptions(key_def))
+		}
+		if err := o.Error(); err != nil {
+			delete(c.objects, obj_name)
+			return err
+		}
+		gotrace.Trace("Object '%s' created by plugin '%s'.", obj_name, plugin)
+	}
+
+	// Add actions not already defined on the object.
+	actions := make([]string, 0, len(obj_def.Actions))
+	for _, action_name := range obj_def.Actions {
+		if _, found := o.actions[action_name]; found {
+			continue
+		}
+		if action, found := c.actions[action_name]; !found {
+			gotrace.Warning("Plugin '%s': unknown action '%s' for object '%s'. Ignored.", plugin, action_name, obj_name)
+			continue
+		} else if action.internal_only {
+			gotrace.Warning("Plugin '%s': action '%s' cannot be enhanced by plugins. Object '%s' action ignored.",
+				plugin, action_name, obj_name)
+			continue
+		}
+		actions = append(actions, action_name)
+	}
+	if len(actions) > 0 && o.DefineActions(actions...) == nil {
+		return o.Error()
+	}
+	for _, action_name := range obj_def.Actions {
+		if action, found := o.actions[action_name]; found {
+			action.plugins = addPlugin(action.plugins, plugin)
+		}
+	}
+
+	for _, field_name := range sortedKeys(obj_def.Flags) {
+		flag_def := obj_def.Flags[field_name]
+		if field, found := o.fields[field_name]; found {
+			if err := c.checkPluginField(field, plugin, pluginFieldType(flag_def), flag_def.Help,
+				flag_def.FormatRegexp, pluginFlagOptions(flag_def)); err != nil {
+				return err
+			}
+		} else if o.AddField(pluginFieldType(flag_def), field_name, flag_def.Help, flag_def.FormatRegexp,
+			pluginFlagOptions(flag_def)) == nil {
+			return o.Error()
+		}
+		field := o.fields[field_name]
+		field.plugins = addPlugin(field.plugins, plugin)
+
+		if err := o.addPluginFieldParams(plugin, field, obj_def, flag_def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addPluginFieldParams add the field flag (or arg for a key) to each object action requested by the plugin
+// and not already declared.
+func (o *ForjObject) addPluginFieldParams(plugin string, field *ForjField, obj_def goforjj.YamlObject, flag_def goforjj.YamlFlag) error {
+	actions_list := flag_def.Actions
+	if len(actions_list) == 0 {
+		actions_list = obj_def.Actions
+	}
+
+	actions := make([]string, 0, len(actions_list))
+	for _, action_name := range actions_list {
+		action, found := o.actions[action_name]
+		if !found {
+			continue
+		}
+		if p, found := action.params[field.name]; found {
+			if f, ok := p.(*ForjFlag); ok {
+				f.plugins = addPlugin(f.plugins, plugin)
+			}
+			continue
+		}
+		actions = append(actions, action_name)
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+
+	o.OnActions(actions...)
+	if field.key && !o.single {
+		if o.AddArg(field.name, pluginFlagOptions(flag_def).Required()) == nil {
+			return o.Error()
+		}
+		return nil
+	}
+	if o.AddFlag(field.name, pluginFlagOptions(flag_def)) == nil {
+		return o.Error()
+	}
+	for _, action_name := range actions {
+		if f, ok := o.actions[action_name].params[field.name].(*ForjFlag); ok {
+			f.plugins = addPlugin(f.plugins, plugin)
+		}
+	}
 	return nil
 }
+
+// loadPluginTaskFlags add plugin flags to an application action.
+func (c *ForjCli) loadPluginTaskFlags(plugin, action_name string, flags map[string]goforjj.YamlFlag) error {
+	action, found := c.actions[action_name]
+	if !found {
+		gotrace.Trace("Plugin '%s': task '%s' is not a cli action. Ignored.", plugin, action_name)
+		return nil
+	}
+	if action.internal_only {
+		return fmt.Errorf("Action '%s' cannot be enhanced by plugins.", action_name)
+	}
+
+	for _, flag_name := range sortedKeys(flags) {
+		if p, found := action.params[flag_name]; found {
+			if f, ok := p.(*ForjFlag); ok {
+				f.plugins = addPlugin(f.plugins, plugin)
+			}
+			continue
+		}
+		flag_def := flags[flag_name]
+		f := new(ForjFlag)
+		f.set_cmd(action.cmd, pluginFieldType(flag_def), flag_name, flag_def.Help, pluginFlagOptions(flag_def))
+		f.plugins = addPlugin(f.plugins, plugin)
+		action.params[flag_name] = f
+	}
+	return nil
+}
+
+// pluginFieldType return the cli param type from the plugin flag type. String by default.
+//
+// Enum is not supported as plugins do not give the list of accepted values.
+func pluginFieldType(flag_def goforjj.YamlFlag) string {
+	switch flag_def.Type {
+	case Bool, Int, Float, Duration, URL, Path, StringSlice, StringMap:
+		return flag_def.Type
+	}
+	return String
+}
+
+// pluginFlagOptions convert plugin flag options to ForjOpts.
+func pluginFlagOptions(flag_def goforjj.YamlFlag) *ForjOpts {
+	opts := Opts()
+	if flag_def.Options.Required {
+		opts.Required()
+	}
+	if flag_def.Options.Default != "" {
+		opts.Default(flag_def.Options.Default)
+	}
+	if flag_def.Options.Envar != "" {
+		opts.Envar(flag_def.Options.Envar)
+	}
+	if flag_def.Options.Hidden {
+		opts.Hidden()
+	}
+	return opts
+}
+
+// addPlugin add a plugin name to the list if not already there.
+func addPlugin(plugins []string, plugin string) []string {
+	for _, name := range plugins {
+		if name == plugin {
+			return plugins
+		}
+	}
+	return append(plugins, plugin)
+}
+
+// sortedKeys return map keys sorted, to load plugin data in a predictable order.
+func sortedKeys(m interface{}) (keys []string) {
+	switch v := m.(type) {
+	case map[string]goforjj.YamlObject:
+		keys = make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]goforjj.YamlFlag:
+		keys = make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]map[string]goforjj.YamlFlag:
+		keys = make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"testing"
+
+	"github.com/forj-oss/goforjj"
+)
+
+func TestForjCli_LoadPluginData(t *testing.T) {
+	t.Log("Expect LoadPluginData() to create objects, fields and flags from plugin data.")
+
+	// --- Setting test context ---
+	const (
+		plugin   = "github"
+		repo     = "repo"
+		name     = "name"
+		title    = "title"
+		private  = "private"
+		add      = "add"
+		add_help = "add help"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.NewActions(add, add_help, "add %s", false)
+	c.NewActions(update, update_help, "update %s", false)
+
+	data := &goforjj.YamlPluginComm{
+		Name: plugin,
+		Objects: map[string]goforjj.YamlObject{
+			repo: {
+				Actions:            []string{add, update},
+				Help:               "repository",
+				Identified_by_flag: name,
+				Flags: map[string]goforjj.YamlFlag{
+					name:    {Help: "repo name"},
+					title:   {Help: "repo title", Options: goforjj.YamlFlagOptions{Default: "my title"}},
+					private: {Help: "private repo", Type: Bool, Actions: []string{add}},
+				},
+			},
+		},
+	}
+
+	// --- Run the test ---
+	err := c.LoadPluginData(data)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected LoadPluginData() to return no error. Got %s", err)
+		return
+	}
+	o := c.GetObject(repo)
+	if o == nil {
+		t.Errorf("Expected object '%s' to be created. Got nil.", repo)
+		return
+	}
+	if v := o.getKeyName(); v != name {
+		t.Errorf("Expected object key to be '%s'. Got '%s'.", name, v)
+	}
+	for _, field := range []string{name, title, private} {
+		if !o.HasField(field) {
+			t.Errorf("Expected object field '%s' to exist. Not found.", field)
+			continue
+		}
+		if v := o.fields[field].plugins; len(v) != 1 || v[0] != plugin {
+			t.Errorf("Expected field '%s' to be owned by '%s'. Got '%s'.", field, plugin, v)
+		}
+	}
+	if v := o.fields[private].value_type; v != Bool {
+		t.Errorf("Expected field '%s' to be '%s'. Got '%s'.", private, Bool, v)
+	}
+	for _, action := range []string{add, update} {
+		oa, found := o.actions[action]
+		if !found {
+			t.Errorf("Expected object action '%s' to exist. Not found.", action)
+			continue
+		}
+		if v := oa.plugins; len(v) != 1 || v[0] != plugin {
+			t.Errorf("Expected object action '%s' to be owned by '%s'. Got '%s'.", action, plugin, v)
+		}
+		if _, ok := oa.params[name].(*ForjArg); !ok {
+			t.Errorf("Expected object action '%s' to have key arg '%s'. Not found.", action, name)
+		}
+		if _, ok := oa.params[title].(*ForjFlag); !ok {
+			t.Errorf("Expected object action '%s' to have flag '%s'. Not found.", action, title)
+		}
+	}
+	if _, found := o.actions[add].params[private]; !found {
+		t.Errorf("Expected flag '%s' on action '%s'. Not found.", private, add)
+	}
+	if _, found := o.actions[update].params[private]; found {
+		t.Errorf("Expected flag '%s' to not exist on action '%s'. Found it.", private, update)
+	}
+	if f := app.GetFlag(add, repo, title); f == nil {
+		t.Errorf("Expected kingpin flag '%s' to exist. Got nil.", title)
+	} else if !f.IsDefault("my title") {
+		t.Errorf("Expected kingpin flag '%s' default to be set.", title)
+	}
+
+	// Loading a second plugin on the same object only registers it.
+	data.Name = "gitlab"
+	if err = c.LoadPluginData(data); err != nil {
+		t.Errorf("Expected LoadPluginData() to return no error. Got %s", err)
+		return
+	}
+	if v := o.fields[title].plugins; len(v) != 2 || v[1] != "gitlab" {
+		t.Errorf("Expected field '%s' to be owned by 2 plugins. Got '%s'.", title, v)
+	}
+	if v := o.actions[add].plugins; len(v) != 2 {
+		t.Errorf("Expected object action '%s' to be owned by 2 plugins. Got '%s'.", add, v)
+	}
+}
+
+func TestForjCli_LoadPluginData_errors(t *testing.T) {
+	t.Log("Expect LoadPluginData() to report invalid plugin data.")
+
+	// --- Setting test context ---
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.NewActions(update, update_help, "update %s", false)
+	c.NewActions(create, create_help, "create %s", true)
+
+	// --- Run the test ---
+	err := c.LoadPluginData(nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected LoadPluginData(nil) to fail. Got no error.")
+	}
+
+	// --- Run the test ---
+	err = c.LoadPluginData(&goforjj.YamlPluginComm{
+		Name: "plugin",
+		Objects: map[string]goforjj.YamlObject{
+			"app": {Actions: []string{update}, Identified_by_flag: "name"},
+		},
+	})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected LoadPluginData() to fail on missing key field. Got no error.")
+	}
+	if c.GetObject("app") != nil {
+		t.Error("Expected object 'app' to not be created. Got one.")
+	}
+
+	// --- Run the test ---
+	err = c.LoadPluginData(&goforjj.YamlPluginComm{
+		Name:  "plugin",
+		Tasks: map[string]map[string]goforjj.YamlFlag{create: {"debug": {Help: "debug"}}},
+	})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected LoadPluginData() to fail on internal action. Got no error.")
+	}
+}
@@ -0,0 +1,375 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CompleteCommand is the hidden command called by completion scripts to get suggestions.
+//
+// Ex: <app> __complete create repo --na
+const CompleteCommand = "__complete"
+
+// Supported completion shells.
+const (
+	Bash = "bash"
+	Zsh  = "zsh"
+	Fish = "fish"
+)
+
+var completionFuncSanitizer = regexp.MustCompile("[^A-Za-z0-9_]+")
+
+const bashCompletionScript = `# bash completion for {{prog}}
+_{{func}}_completion() {
+    local IFS=$'\n'
+    COMPREPLY=( $("${COMP_WORDS[0]}" ` + CompleteCommand + ` "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null) )
+}
+complete -o default -F _{{func}}_completion {{prog}}
+`
+
+const zshCompletionScript = `#compdef {{prog}}
+# zsh completion for {{prog}}
+_{{func}}() {
+    local -a completions
+    completions=("${(@f)$("${words[1]}" ` + CompleteCommand + ` "${(@)words[2,$CURRENT]}" 2>/dev/null)}")
+    compadd -- $completions
+}
+compdef _{{func}} {{prog}}
+`
+
+const fishCompletionScript = `# fish completion for {{prog}}
+function __{{func}}_complete
+    set -l args (commandline -opc)
+    {{prog}} ` + CompleteCommand + ` $args[2..-1] (commandline -ct) 2>/dev/null
+end
+complete -c {{prog}} -f -a '(__{{func}}_complete)'
+`
+
+// CompletionScript return the completion script for a shell (Bash, Zsh or Fish).
+//
+// The script calls back the application with CompleteCommand to get suggestions. So the application must call
+// HandleCompletion before parsing the command line.
+//
+// prog is the application binary name. If empty, the application name is used.
+func (c *ForjCli) CompletionScript(shell, prog string) (string, error) {
+	if c == nil {
+		return "", fmt.Errorf("Unable to generate completion script. Cli is nil.")
+	}
+	if prog == "" {
+		prog = c.App.Name()
+	}
+	var script string
+	switch shell {
+	case Bash:
+		script = bashCompletionScript
+	case Zsh:
+		script = zshCompletionScript
+	case Fish:
+		script = fishCompletionScript
+	default:
+		return "", fmt.Errorf("Unable to generate completion script. Shell '%s' is not supported. Use %s, %s or %s.",
+			shell, Bash, Zsh, Fish)
+	}
+	script = strings.Replace(script, "{{func}}", completionFuncSanitizer.ReplaceAllString(prog, "_"), -1)
+	return strings.Replace(script, "{{prog}}", prog, -1), nil
+}
+
+// HandleCompletion write completion suggestions if args is a completion request. (args[0] == CompleteCommand)
+//
+// It returns false if args is not a completion request. Ex:
+//
+//	if c.HandleCompletion(os.Args[1:], context, os.Stdout) {
+//	    os.Exit(0)
+//	}
+func (c *ForjCli) HandleCompletion(args []string, context interface{}, w io.Writer) bool {
+	if c == nil || len(args) == 0 || args[0] != CompleteCommand {
+		return false
+	}
+	for _, suggestion := range c.Complete(args[1:], context) {
+		fmt.Fprintln(w, suggestion)
+	}
+	return true
+}
+
+// forjCompletion is the command line position identified from words already given.
+type forjCompletion struct {
+	action  *ForjAction
+	object  *ForjObject
+	list    *ForjObjectList
+	params  map[string]ForjParam
+	args    []string  // Positional args given after the action/object command.
+	pending ForjParam // Flag waiting for its value.
+	// Instances given by object lists (list command arg or flag list), to build instance flags.
+	instances map[*ForjObjectList][]string
+}
+
+// Complete return the list of suggestions for the last word of args. args are the words following the application
+// name, the last one being the word to complete. (possibly empty)
+//
+// The cli context is loaded from previous words, so that flags added by hooks and object instances flags are known.
+// Missing values are not asked, even in interactive mode. (See Interactive)
+func (c *ForjCli) Complete(args []string, context interface{}) []string {
+	if c == nil {
+		return nil
+	}
+	cur := ""
+	words := args
+	if len(args) > 0 {
+		cur = args[len(args)-1]
+		words = args[:len(args)-1]
+	}
+
+	// Missing values are never asked while completing.
+	prompter := c.prompter
+	c.prompter = nil
+	defer func() { c.prompter = prompter }()
+
+	// Errors are expected, as the command line is not complete.
+	c.loadContext(words, context)
+
+	pos := c.completionPosition(words)
+	var suggestions []string
+	switch {
+	case pos.pending != nil:
+		suggestions = c.completeFlagValue(pos.pending, cur)
+	case strings.HasPrefix(cur, "-"):
+		suggestions = c.completeFlags(pos)
+	case pos.action == nil:
+		for name := range c.actions {
+			suggestions = append(suggestions, name)
+		}
+	case pos.object == nil && pos.list == nil:
+		for name, o := range c.objects {
+			if _, found := o.actions[pos.action.name]; found {
+				suggestions = append(suggestions, name)
+			}
+			for _, l := range o.list {
+				if _, found := l.actions[pos.action.name]; found {
+					suggestions = append(suggestions, l.getParamListObjectName())
+				}
+			}
+		}
+	case pos.list != nil && len(pos.args) == 0:
+		suggestions = completeList(c.completeInstances(pos.list.obj), pos.list.sep, cur)
+	case pos.object != nil && len(pos.args) == 0 && pos.hasArgs():
+		suggestions = c.completeInstances(pos.object)
+	}
+	return filterSuggestions(suggestions, cur)
+}
+
+// completionPosition identify the action, object, list and flag waiting for a value from words.
+func (c *ForjCli) completionPosition(words []string) (pos forjCompletion) {
+	pos.instances = make(map[*ForjObjectList][]string)
+	for _, word := range words {
+		if pos.pending != nil {
+			if fl, ok := pos.pending.(*ForjFlagList); ok {
+				pos.instances[fl.obj] = append(pos.instances[fl.obj], fl.obj.keys(word)...)
+			}
+			pos.pending = nil
+			continue
+		}
+		if strings.HasPrefix(word, "-") {
+			if strings.Contains(word, "=") {
+				continue
+			}
+			if p := c.completionFlag(pos, strings.TrimLeft(word, "-")); p != nil && p.Type() != Bool {
+				pos.pending = p
+			}
+			continue
+		}
+		switch {
+		case pos.action == nil:
+			if a, found := c.actions[word]; found {
+				pos.action = a
+				pos.params = a.params
+			}
+		case pos.object == nil && pos.list == nil:
+			if !c.completionCommand(&pos, word) {
+				pos.args = append(pos.args, word)
+			}
+		default:
+			if pos.list != nil && len(pos.args) == 0 {
+				pos.instances[pos.list] = append(pos.instances[pos.list], pos.list.keys(word)...)
+			}
+			pos.args = append(pos.args, word)
+		}
+	}
+	return
+}
+
+// completionCommand select the object or object list command identified by word.
+func (c *ForjCli) completionCommand(pos *forjCompletion, word string) bool {
+	for name, o := range c.objects {
+		if oa, found := o.actions[pos.action.name]; found && name == word {
+			pos.object = o
+			pos.params = oa.params
+			return true
+		}
+		for _, l := range o.list {
+			if la, found := l.actions[pos.action.name]; found && l.getParamListObjectName() == word {
+				pos.list = l
+				pos.params = la.params
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// completionFlag return the flag param named name at the current position. nil if not found.
+func (c *ForjCli) completionFlag(pos forjCompletion, name string) ForjParam {
+	if p, found := pos.params[name]; found {
+		return p
+	}
+	if f, found := c.flags[name]; found {
+		return f
+	}
+	for l, instances := range pos.instances {
+		for _, instance := range instances {
+			field_name := strings.TrimPrefix(name, instance+"-")
+			if field_name == name || l.isListField(field_name) {
+				continue
+			}
+			if f := l.obj.getInstanceField(instance, field_name); f != nil {
+				return &ForjFlag{
+					name:          name,
+					value_type:    f.value_type,
+					options:       f.options,
+					list:          l,
+					instance_name: instance,
+					field_name:    field_name,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasArgs return true if the current params have positional args.
+func (pos forjCompletion) hasArgs() bool {
+	for _, p := range pos.params {
+		switch p.(type) {
+		case *ForjArg, *ForjArgList:
+			return true
+		}
+	}
+	return false
+}
+
+// completeFlags return the flags names available at the current position.
+func (c *ForjCli) completeFlags(pos forjCompletion) (flags []string) {
+	for name := range c.flags {
+		flags = append(flags, "--"+name)
+	}
+	for name, p := range pos.params {
+		switch p.(type) {
+		case *ForjFlag, *ForjFlagList:
+			flags = append(flags, "--"+name)
+		}
+	}
+	// Instance flags are added to the context only when the list is parsed. So they are built from the lists given.
+	for l, instances := range pos.instances {
+		for _, instance := range instances {
+			for _, field_name := range l.obj.getInstanceFieldsName(instance) {
+				if !l.isListField(field_name) {
+					flags = append(flags, "--"+instance+"-"+field_name)
+				}
+			}
+		}
+	}
+	return
+}
+
+// completeFlagValue return the values suggested for a flag.
+func (c *ForjCli) completeFlagValue(p ForjParam, cur string) []string {
+	switch param := p.(type) {
+	case *ForjFlagList:
+		return completeList(c.completeInstances(param.obj.obj), param.obj.sep, cur)
+	case *ForjFlag:
+		if enum := param.options.GetEnum(); len(enum) > 0 {
+			return enum
+		}
+		if param.obj != nil && param.field_name == param.obj.getKeyName() {
+			return c.completeInstances(param.obj)
+		}
+	}
+	return nil
+}
+
+// completeInstances return known instances of an object. Declared instances and instances loaded in values.
+func (c *ForjCli) completeInstances(o *ForjObject) []string {
+	if o == nil || o.single {
+		return nil
+	}
+	instances := o.GetInstances()
+	if r, found := c.values[o.name]; found {
+		for key := range r.records {
+			instances = append(instances, key)
+		}
+	}
+	return instances
+}
+
+// keys return the list key values given in a list string.
+func (l *ForjObjectList) keys(value string) (keys []string) {
+	key_name := l.obj.getKeyName()
+	for _, v := range Split(" *"+l.sep+" *", value, l.sep) {
+		res := l.ext_regexp.FindStringSubmatch(v)
+		for index, field_name := range l.fields_name {
+			if field_name == key_name && int(index) < len(res) && res[index] != "" {
+				keys = append(keys, res[index])
+			}
+		}
+	}
+	return
+}
+
+// getInstanceFieldsName return the object fields and instance additional fields names.
+func (o *ForjObject) getInstanceFieldsName(instance string) (names []string) {
+	for name := range o.fields {
+		names = append(names, name)
+	}
+	if i, found := o.instances[instance]; found {
+		for name := range i.additional_fields {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+// isListField return true if the field is given by the list string.
+func (l *ForjObjectList) isListField(field_name string) bool {
+	for _, name := range l.fields_name {
+		if name == field_name {
+			return true
+		}
+	}
+	return false
+}
+
+// completeList return list values suggestions. The values already given before the last separator are kept.
+func completeList(values []string, sep, cur string) (ret []string) {
+	prefix := ""
+	if i := strings.LastIndex(cur, sep); i >= 0 {
+		prefix = cur[:i+len(sep)]
+	}
+	for _, v := range values {
+		ret = append(ret, prefix+v)
+	}
+	return
+}
+
+// filterSuggestions return sorted and unique suggestions starting with cur.
+func filterSuggestions(suggestions []string, cur string) (ret []string) {
+	sort.Strings(suggestions)
+	for i, s := range suggestions {
+		if !strings.HasPrefix(s, cur) || (i > 0 && s == suggestions[i-1]) {
+			continue
+		}
+		ret = append(ret, s)
+	}
+	return
+}
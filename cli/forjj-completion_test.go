@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"bytes"
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_Complete(t *testing.T) {
+	t.Log("Expect ForjCli_Complete() to suggest actions, objects, flags and values.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{""}, []string{create, update}},
+		{[]string{"up"}, []string{update}},
+		{[]string{create, ""}, []string{"repo", "repos"}},
+		{[]string{create, "repo", "--"}, []string{"--debug", "--kind", "--name", "--title"}},
+		{[]string{create, "repo", "--kind", ""}, []string{"github", "gitlab"}},
+		{[]string{create, "repo", "--name", "i"}, []string{"infra"}},
+		{[]string{create, "repos", ""}, []string{"app", "infra"}},
+		{[]string{create, "repos", "infra,"}, []string{"infra,app", "infra,infra"}},
+		{[]string{create, "repos", "infra,app", "--infra-"}, []string{"--infra-kind", "--infra-title"}},
+		{[]string{create, "repos", "infra", "--infra-kind", "git"}, []string{"github", "gitlab"}},
+		{[]string{update, "--repos", "a"}, []string{"app"}},
+		{[]string{update, "--repos", "app", "--app-t"}, []string{"--app-title"}},
+		{[]string{create, "repo", "--debug", "x", ""}, nil},
+	}
+
+	for _, test := range tests {
+		// --- Run the test ---
+		ret := c.Complete(test.args, nil)
+
+		// --- Start testing ---
+		if strings.Join(ret, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("Expected '%s' to suggest %s. Got %s", strings.Join(test.args, " "), test.expected, ret)
+		}
+	}
+}
+
+func TestForjCli_HandleCompletion(t *testing.T) {
+	t.Log("Expect ForjCli_HandleCompletion() to print suggestions only on completion requests.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+	out := new(bytes.Buffer)
+
+	// --- Run the test ---
+	ret := c.HandleCompletion([]string{create, ""}, nil, out)
+
+	// --- Start testing ---
+	if ret || out.Len() != 0 {
+		t.Errorf("Expected a standard command line to not be handled. Got '%s'", out)
+	}
+
+	// --- Run the test ---
+	ret = c.HandleCompletion([]string{CompleteCommand, create, "re"}, nil, out)
+
+	// --- Start testing ---
+	if !ret {
+		t.Error("Expected the completion request to be handled.")
+	}
+	if v := out.String(); v != "repo\nrepos\n" {
+		t.Errorf("Expected suggestions to be printed one by line. Got '%s'", v)
+	}
+}
+
+func TestForjCli_CompletionScript(t *testing.T) {
+	t.Log("Expect ForjCli_CompletionScript() to generate bash, zsh and fish scripts.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+
+	for shell, expected := range map[string]string{
+		Bash: "complete -o default -F _my_app_completion my-app",
+		Zsh:  "compdef _my_app my-app",
+		Fish: "complete -c my-app -f -a '(__my_app_complete)'",
+	} {
+		// --- Run the test ---
+		script, err := c.CompletionScript(shell, "my-app")
+
+		// --- Start testing ---
+		if err != nil {
+			t.Errorf("Expected %s script to be generated. Got '%s'", shell, err)
+			continue
+		}
+		if !strings.Contains(script, expected) || !strings.Contains(script, CompleteCommand) {
+			t.Errorf("Expected %s script to contain '%s'. Got:\n%s", shell, expected, script)
+		}
+	}
+
+	// --- Run the test ---
+	if _, err := c.CompletionScript("powershell", ""); err == nil {
+		t.Error("Expected unsupported shell to fail.")
+	}
+}
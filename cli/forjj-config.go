@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/forj-oss/forjj-modules/trace"
+	"gopkg.in/yaml.v2"
+)
+
+// Configuration files provide defaults to application flags and object instances fields.
+//
+// A configuration file is a YAML (.yaml, .yml), JSON (.json) or TOML (.toml) document:
+//
+//   flags:            # Application flags defaults
+//     debug: true
+//   objects:
+//     repo:           # object name
+//       myrepo:       # object instance
+//         title: "My repo"
+//     workspace:      # single object fields are given directly.
+//       path: /tmp/ws
+//
+// Precedence is: cli > envar > config files (last added first) > ForjOpts default.
+
+// forjConfigLayer is the content of a configuration file.
+type forjConfigLayer struct {
+	file    string
+	flags   map[string]interface{} // Application flags values
+	objects map[string]interface{} // Objects instances fields values. Interpreted when applied to objects.
+}
+
+// AddConfigFile add a configuration file layer. Files added later have precedence over previous ones.
+//
+// Ex: c.AddConfigFile("~/.forjj/config.yaml").AddConfigFile(".forjj.yaml")
+//
+// A missing file is ignored. '~/' is replaced by the user home directory.
+func (c *ForjCli) AddConfigFile(file string) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.config_files = append(c.config_files, file)
+	return c
+}
+
+// GetConfigFiles return the list of configuration files added.
+func (c *ForjCli) GetConfigFiles() []string {
+	if c == nil {
+		return nil
+	}
+	return c.config_files
+}
+
+// loadConfigFiles read all configuration files, and set application flags defaults from them.
+func (c *ForjCli) loadConfigFiles() error {
+	c.config = nil
+	for _, file := range c.config_files {
+		layer, err := loadConfigLayer(expandHome(file))
+		if err != nil {
+			return err
+		}
+		if layer != nil {
+			c.config = append(c.config, layer)
+		}
+	}
+
+	for _, layer := range c.config {
+		for name, v := range layer.flags {
+			f, found := c.flags[name]
+			if !found {
+				gotrace.Warning("Config file '%s': Unknown application flag '%s'. Ignored.", layer.file, name)
+				continue
+			}
+			if f.isSecret() {
+				gotrace.Warning("Config file '%s': Secret flag '%s' can not be set by a file default. Ignored.",
+					layer.file, name)
+				continue
+			}
+			value, err := importValue(f.value_type, v, false)
+			if err != nil {
+				return fmt.Errorf("Config file '%s': Invalid flag '%s' value. %s", layer.file, name, err)
+			}
+			raw := rawString(value)
+			if f.options == nil {
+				f.options = Opts()
+			}
+			f.options.FileDefault(raw, layer.file)
+			gotrace.Trace("set flag %s default to '%s' from '%s'", name, raw, layer.file)
+			f.flag.Default(raw)
+		}
+	}
+	return nil
+}
+
+// applyConfigValues set objects instances fields from configuration files.
+//
+// Only existing records are updated. A field value is replaced only if not set or set by a default.
+func (c *ForjCli) applyConfigValues() error {
+	for _, layer := range c.config {
+		for object_name, value := range layer.objects {
+			o, found := c.objects[object_name]
+			if !found {
+				gotrace.Trace("Config file '%s': Unknown object '%s'. Ignored.", layer.file, object_name)
+				continue
+			}
+			instances, ok := configMap(value)
+			if !ok {
+				return fmt.Errorf("Config file '%s': object '%s' must be a map.", layer.file, object_name)
+			}
+			if o.single {
+				instances = map[string]interface{}{o.name: instances}
+			}
+			for instance, value := range instances {
+				fields, ok := configMap(value)
+				if !ok {
+					return fmt.Errorf("Config file '%s': object '%s' instance '%s' must be a map of fields.",
+						layer.file, object_name, instance)
+				}
+				if err := c.applyConfigFields(layer.file, o, instance, fields); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applyConfigFields set an object instance fields from a configuration file.
+func (c *ForjCli) applyConfigFields(file string, o *ForjObject, instance string, fields map[string]interface{}) error {
+	r, found := c.values[o.name]
+	if !found {
+		return nil
+	}
+	data, found := r.records[instance]
+	if !found {
+		return nil
+	}
+	for field_name, v := range fields {
+		field := o.getInstanceField(instance, field_name)
+		if field == nil {
+			gotrace.Trace("Config file '%s': Unknown field '%s/%s'. Ignored.", file, o.name, field_name)
+			continue
+		}
+		if !data.configurable(field_name) {
+			continue
+		}
+		if field.isSecret() {
+			gotrace.Warning("Config file '%s': Secret field '%s/%s' can not be set by a configuration file. Ignored.",
+				file, o.name, field_name)
+			continue
+		}
+		value, err := importValue(field.value_type, v, false)
+		if err != nil {
+			return fmt.Errorf("Config file '%s': Invalid '%s/%s' field '%s' value. %s",
+				file, o.name, instance, field_name, err)
+		}
+		origin := ForjValueOrigin{Source: SourceFile, Name: file}
+		if err := field.checkValue(instance, value, origin.String()); err != nil {
+			return err
+		}
+		if _, err := data.setFrom(field.value_type, field_name, value, origin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keepFileValue return true if an attribute value loaded from a configuration file must not be replaced by the
+// param value. It is replaced only if the param is given on the command line or by envar.
+func (c *ForjCli) keepFileValue(object, instance, field string, p ForjParam) bool {
+	if o, found, _ := c.GetValueOrigin(object, instance, field); !found || o.Source != SourceFile {
+		return false
+	}
+	if c.cli_context.context == nil {
+		return true
+	}
+	v, found := p.GetContextValue(c.cli_context.context)
+	if !found || v == nil {
+		return true
+	}
+	switch paramOrigin(c.cli_context.context, p, v).Source {
+	case SourceCli, SourceEnvar:
+		return false
+	}
+	return true
+}
+
+// configurable return true if the attribute can be set from a configuration file.
+func (d *ForjData) configurable(key string) bool {
+	if v, found := d.attrs[key]; !found || v == nil {
+		return true
+	}
+	switch d.origins[key].Source {
+	case "", SourceDefault, SourceFile:
+		return true
+	}
+	return false
+}
+
+// getInstanceField return the object field or the instance additional field. nil if not found.
+func (o *ForjObject) getInstanceField(instance, name string) *ForjField {
+	if f, found := o.fields[name]; found {
+		return f
+	}
+	if i, found := o.instances[instance]; found {
+		if f, found := i.additional_fields[name]; found {
+			return f
+		}
+	}
+	return nil
+}
+
+// loadConfigLayer read a configuration file. nil is returned if the file does not exist.
+func loadConfigLayer(file string) (*forjConfigLayer, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		gotrace.Trace("Config file '%s' not found. Ignored.", file)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read config file '%s'. %s", file, err)
+	}
+
+	doc := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".json":
+		err = json.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("Unable to read config file '%s'. Format not supported. Use yaml, json or toml.", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read config file '%s'. %s", file, err)
+	}
+
+	layer := &forjConfigLayer{file: file}
+	for key, value := range doc {
+		m, ok := configMap(value)
+		if !ok {
+			return nil, fmt.Errorf("Config file '%s': '%s' must be a map.", file, key)
+		}
+		switch key {
+		case "flags":
+			layer.flags = m
+		case "objects":
+			layer.objects = m
+		default:
+			gotrace.Warning("Config file '%s': Unknown section '%s'. Ignored.", file, key)
+		}
+	}
+	gotrace.Trace("Config file '%s' loaded.", file)
+	return layer, nil
+}
+
+// configMap return a decoded document map with string keys. yaml returns map[interface{}]interface{}.
+func configMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{})
+		for k, v := range m {
+			ret[fmt.Sprintf("%v", k)] = v
+		}
+		return ret, true
+	}
+	return nil, false
+}
+
+// expandHome replace a leading '~/' by the user home directory.
+func expandHome(file string) string {
+	if strings.HasPrefix(file, "~/") {
+		return filepath.Join(os.Getenv("HOME"), file[2:])
+	}
+	return file
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfigFiles create configuration files in a temporary directory and return their path by name.
+func writeConfigFiles(t *testing.T, files map[string]string) (string, map[string]string) {
+	dir, err := ioutil.TempDir("", "forjj-cli-config")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory. %s", err)
+	}
+	paths := make(map[string]string)
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := ioutil.WriteFile(paths[name], []byte(content), 0644); err != nil {
+			t.Fatalf("Unable to write '%s'. %s", name, err)
+		}
+	}
+	return dir, paths
+}
+
+func TestForjCli_Parse_ConfigFiles(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to load defaults from layered config files.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd  = "cmd:"
+		c_repo = "repo"
+		envar  = "FORJJ_CLI_TEST_CONFIG_DOCKER"
+	)
+	dir, files := writeConfigFiles(t, map[string]string{
+		"user.json": `{
+  "flags": {"infra": "user-infra", "debug": "true"},
+  "objects": {
+    "repo": {"myrepo": {"title": "User title", "port": 1, "labels": ["a", "b"]}},
+    "workspace": {"path": "/user/ws"}
+  }
+}`,
+		"project.yaml": `
+flags:
+  infra: project-infra
+objects:
+  repo:
+    myrepo:
+      port: 2
+      vars:
+        k1: v1
+`,
+		"workspace.toml": `
+[objects.workspace]
+docker = "/toml/docker"
+`,
+	})
+	defer os.RemoveAll(dir)
+	os.Setenv(envar, "/envar/docker")
+	defer os.Unsetenv(envar)
+
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AddAppFlag(String, "infra", "infra help", Opts().Default("default-infra"))
+	c.AddAppFlag(String, "debug", "debug help", nil)
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(workspace, "workspace help", "").Single().
+		AddField(String, "path", "path help", "", Opts().Default("/default/ws")).
+		AddField(String, "docker", "docker help", "", Opts().Envar(envar))
+	c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(String, "title", "title help", "", Opts().Default("Default title")).
+		AddField(Int, "port", "port help", "", nil).
+		AddField(StringSlice, "labels", "labels help", "", nil).
+		AddField(StringMap, "vars", "vars help", "", nil).
+		AddField(String, "owner", "owner help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("port", nil).
+		AddFlag("labels", nil).
+		AddFlag("vars", nil).
+		AddFlag("owner", nil)
+	c.AddConfigFile(files["user.json"]).
+		AddConfigFile(files["project.yaml"]).
+		AddConfigFile(files["workspace.toml"]).
+		AddConfigFile(filepath.Join(dir, "missing.yaml"))
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo", "labels", "cli"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v := c.values[internal_app].records[app.Name()].GetString("infra"); v != "project-infra" {
+		t.Errorf("Expected infra to come from the project file. Got '%s'", v)
+	}
+	if o, _, _ := c.GetValueOrigin(internal_app, app.Name(), "infra"); o.Source != SourceFile ||
+		o.Name != files["project.yaml"] {
+		t.Errorf("Expected infra origin to be the project file. Got %#v", o)
+	}
+	if v, _, _, _ := c.GetStringValue(c_repo, "myrepo", "title"); v != "User title" {
+		t.Errorf("Expected title to come from the user file over the declared default. Got '%s'", v)
+	}
+	if v, _, _ := c.GetIntValue(c_repo, "myrepo", "port"); v != 2 {
+		t.Errorf("Expected port to come from the project file. Got %d", v)
+	}
+	if v, _, _ := c.GetStringSliceValue(c_repo, "myrepo", "labels"); len(v) != 1 || v[0] != "cli" {
+		t.Errorf("Expected labels to come from the cli. Got %s", v)
+	}
+	if v, _, _ := c.GetStringMapValue(c_repo, "myrepo", "vars"); v["k1"] != "v1" {
+		t.Errorf("Expected vars to come from the project file. Got %s", v)
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "vars"); o.String() != "file '"+files["project.yaml"]+"'" {
+		t.Errorf("Expected vars origin to be the project file. Got '%s'", o)
+	}
+	if v, found, _, _ := c.GetStringValue(c_repo, "myrepo", "owner"); found {
+		t.Errorf("Expected owner to not be set. Got '%s'", v)
+	}
+	if v, _, _, _ := c.GetStringValue(workspace, workspace, "path"); v != "/user/ws" {
+		t.Errorf("Expected workspace path to come from the user file. Got '%s'", v)
+	}
+	if v, _, _, _ := c.GetStringValue(workspace, workspace, "docker"); v != "/envar/docker" {
+		t.Errorf("Expected workspace docker to come from envar over the toml file. Got '%s'", v)
+	}
+}
+
+func TestForjCli_Parse_InvalidConfigFile(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to fail on invalid config files.")
+
+	// --- Setting test context ---
+	dir, files := writeConfigFiles(t, map[string]string{
+		"bad.yaml":   "objects: [1, 2]",
+		"bad.ini":    "[objects]",
+		"bad-re.yml": "objects:\n  workspace:\n    path: relative",
+	})
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"bad.yaml", "bad.ini", "bad-re.yml"} {
+		c := NewForjCli(kingpinMock.New("Application"))
+		c.NewActions(create, create_help, "", false)
+		c.NewObject(workspace, "workspace help", "").Single().
+			AddField(String, "path", "path help", "/.*", nil)
+		c.AddConfigFile(files[name])
+
+		// --- Run the test ---
+		_, err := c.Parse([]string{"cmd:" + create}, nil)
+
+		// --- Start testing ---
+		if err == nil {
+			t.Errorf("Expected Parse() to fail with '%s'. Got no error.", name)
+		}
+	}
+}
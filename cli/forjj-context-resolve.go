@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// DefaultContextMaxPasses is the default maximum number of context resolution passes. See ContextMaxPasses.
+const DefaultContextMaxPasses = 10
+
+// ForjContextPass describes what a context resolution pass has added to the cli definition.
+//
+// Added elements are identified as:
+// - flag:<name>                      Application flag
+// - object:<object>                  Object
+// - field:<object>/<field>           Object field (or field:<object>/<instance>/<field> for instance fields)
+// - list:<object>/<list>             Object list
+// - param:<action>/<name>            Action flag/arg
+// - param:<action>/<object>/<name>   Object or object list action flag/arg
+type ForjContextPass struct {
+	Pass  int
+	Added []string
+}
+
+func (p ForjContextPass) String() string {
+	return fmt.Sprintf("Context pass %d: %d added (%s)", p.Pass, len(p.Added), strings.Join(p.Added, ", "))
+}
+
+// ContextMaxPasses set the maximum number of context resolution passes.
+//
+// At parse time, hooks and instance flags creation are executed and the cli context re-parsed until nothing new is
+// added to the cli definition and no hook reports a change. Parse fails if the context is still changing after max
+// passes. As hooks are executed on each pass, they must be idempotent. (See HookContext)
+// If max is lower than 1, DefaultContextMaxPasses is used.
+func (c *ForjCli) ContextMaxPasses(max int) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.context_max_passes = max
+	return c
+}
+
+// GetContextPasses return the context resolution passes executed by the last parse.
+func (c *ForjCli) GetContextPasses() []ForjContextPass {
+	if c == nil {
+		return nil
+	}
+	return c.context_passes
+}
+
+// resolveContext execute hooks and instance flags creation, then re-parse the cli context until nothing new is added
+// to the cli definition and no hook reports a change. (See HookContext.Changed)
+//
+// Legacy hooks are executed once per parse, as they are not idempotent. (See HookContext)
+func (c *ForjCli) resolveContext(args []string, context interface{}) (err error) {
+	max := c.context_max_passes
+	if max < 1 {
+		max = DefaultContextMaxPasses
+	}
+	c.context_passes = nil
+	c.legacy_hooks = nil
+
+	defer func() {
+		c.hook_pass = 0
+		c.hook_changed = false
+	}()
+
+	previous := c.contextDefinition()
+	for pass := 1; ; pass++ {
+		c.hook_pass = pass
+
+		// Load anything that could be required from any existing flags setup.
+		// Ex: app driver - app object hook. - Add new flags/args/objects
+		//     Settings of Defaults, flags attributes - Application hook. - Update existing flags.
+		c.hook_changed = false
+		if err, _ = c.contextHook(context); err != nil {
+			return
+		}
+
+		// Add instance flags for each object instances to each actions referring to those objects.
+		c.addInstanceFlags()
+
+		current := c.contextDefinition()
+		added := current.added(previous)
+		c.context_passes = append(c.context_passes, ForjContextPass{Pass: pass, Added: added})
+		gotrace.Trace("%s", c.context_passes[len(c.context_passes)-1])
+
+		// The context has been parsed with the current definition. Only reload values set by hooks.
+		if !c.hook_changed && len(added) == 0 {
+			return c.reloadContextData()
+		}
+		if pass >= max {
+			return fmt.Errorf("Unable to resolve the cli context. Still changing after %d passes. Last added: %s",
+				max, strings.Join(added, ", "))
+		}
+
+		// Reparse context as new lists, objects, fields or instance flags became new recognized kingpin params.
+		c.setAutoEnvars()
+		if v, err := c.App.ParseContext(args); v == nil {
+			c.cur_cmds = []clier.CmdClauser{}
+			return err
+		} else {
+			c.cli_context.context = v
+		}
+
+		if err = c.reloadContextData(); err != nil {
+			return
+		}
+		previous = current
+	}
+}
+
+// reloadContextData reload object list instances, application/action layer and single objects values from the
+// current context.
+func (c *ForjCli) reloadContextData() error {
+	// Reload object list instances.
+	var errs ForjErrors
+	errs.Add(c.loadContextListData())
+
+	// ReLoad Application/Action layer information if hooks has added some of them at app/action layer.
+	c.loadAppData()
+
+	// Single objects values are set from defaults/envar, possibly updated by hooks.
+	// Errors are reported with list data errors, so that all of them can be fixed at once.
+	errs.Add(c.checkSingleObjectsValues())
+	return errs.ErrorOrNil()
+}
+
+// forjContextDefinition is the set of elements defined in the cli. Used to detect what a context pass added.
+type forjContextDefinition map[string]bool
+
+// contextDefinition return the current cli definition elements.
+func (c *ForjCli) contextDefinition() forjContextDefinition {
+	def := make(forjContextDefinition)
+	for name := range c.flags {
+		def["flag:"+name] = true
+	}
+	for action_name, action := range c.actions {
+		for name := range action.params {
+			def["param:"+action_name+"/"+name] = true
+		}
+	}
+	for object_name, o := range c.objects {
+		def["object:"+object_name] = true
+		for name := range o.fields {
+			def["field:"+object_name+"/"+name] = true
+		}
+		for instance_name, instance := range o.instances {
+			for name := range instance.additional_fields {
+				def["field:"+object_name+"/"+instance_name+"/"+name] = true
+			}
+		}
+		for action_name, action := range o.actions {
+			for name := range action.params {
+				def["param:"+action_name+"/"+object_name+"/"+name] = true
+			}
+		}
+		for list_name, l := range o.list {
+			def["list:"+object_name+"/"+list_name] = true
+			for action_name, action := range l.actions {
+				for name := range action.params {
+					def["param:"+action_name+"/"+l.getParamListObjectName()+"/"+name] = true
+				}
+			}
+		}
+	}
+	return def
+}
+
+// added return the sorted elements not defined in previous.
+func (d forjContextDefinition) added(previous forjContextDefinition) (added []string) {
+	added = make([]string, 0)
+	for key := range d {
+		if !previous[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	return
+}
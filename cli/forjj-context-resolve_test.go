@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_Parse_ContextResolution(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to load flags added by a second generation of hooks.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	driverHook := func(_ *ForjObject, c *ForjCli, _ interface{}) (error, bool) {
+		if _, found := c.actions[create].params["driver-type"]; found {
+			return nil, false
+		}
+		c.OnActions(create).AddFlag(String, "driver-type", "driver type help", nil)
+		return nil, true
+	}
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(func(_ *ForjObject, c *ForjCli, _ interface{}) (error, bool) {
+			if c.GetObject("driver") != nil {
+				return nil, false
+			}
+			c.NewObject("driver", "driver help", "").
+				AddKey(String, "name", "name help", "", nil).
+				ParseHook(driverHook)
+			return nil, true
+		})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "driver-type", "github"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, _ := c.GetActionStringValue(create, "driver-type"); v != "github" {
+		t.Errorf("Expected 'driver-type' flag added by the second hook to be loaded. Got '%s'", v)
+	}
+	passes := c.GetContextPasses()
+	if len(passes) != 3 {
+		t.Errorf("Expected 3 context passes. Got %d: %s", len(passes), passes)
+		return
+	}
+	if v := strings.Join(passes[0].Added, ","); v != "field:driver/name,object:driver" {
+		t.Errorf("Expected pass 1 to add the driver object. Got '%s'", v)
+	}
+	if v := strings.Join(passes[1].Added, ","); v != "param:"+create+"/driver-type" {
+		t.Errorf("Expected pass 2 to add the driver-type flag. Got '%s'", v)
+	}
+	if len(passes[2].Added) != 0 {
+		t.Errorf("Expected pass 3 to add nothing. Got %s", passes[2].Added)
+	}
+}
+
+func TestForjCli_Parse_ContextMaxPasses(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to fail if the context is still changing after the maximum passes.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.ContextMaxPasses(3)
+
+	count := 0
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		OnParse(func(_ *ForjObject, h *HookContext) error {
+			count++
+			h.Cli.AddAppFlag(String, fmt.Sprintf("flag%d", count), "flag help", nil)
+			return nil
+		})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail. Got no error.")
+	} else if !strings.Contains(err.Error(), "after 3 passes") {
+		t.Errorf("Expected the passes limit error. Got '%s'", err)
+	}
+	if v := len(c.GetContextPasses()); v != 3 {
+		t.Errorf("Expected 3 context passes reported. Got %d", v)
+	}
+}
+
+func TestForjCli_Parse_ContextSinglePass(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to execute hooks once if nothing has changed.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	count := 0
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(func(_ *ForjObject, c *ForjCli, _ interface{}) (error, bool) {
+			count++
+			return nil, false
+		})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected the hook to be executed once. Got %d", count)
+	}
+	if v := len(c.GetContextPasses()); v != 1 {
+		t.Errorf("Expected 1 context pass. Got %d", v)
+	}
+}
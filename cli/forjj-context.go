@@ -8,18 +8,44 @@ import (
 )
 
 type ForjCliContext struct {
-	action  *ForjAction          // Can be only one action
-	object  *ForjObject          // Can be only one object at a time. Ex: forj add repo
-	list    *ForjObjectList      // Can be only one list at a time.
-	context clier.ParseContexter // kingpin interface context.
+	action   *ForjAction          // Can be only one action
+	object   *ForjObject          // Can be only one object at a time. Ex: forj add repo
+	instance string               // Object instance identified by the object key. Ex: forj add repo <instance>
+	list     *ForjObjectList      // Can be only one list at a time.
+	context  clier.ParseContexter // kingpin interface context.
 	// forjj add apps ...
 }
 
+// GetSelectedAction return the name of the action selected by the last parse. Empty if none.
+func (c *ForjCli) GetSelectedAction() string {
+	if c == nil || c.cli_context.action == nil {
+		return ""
+	}
+	return c.cli_context.action.name
+}
+
+// GetSelectedObject return the name of the object selected by the last parse. Empty if none.
+func (c *ForjCli) GetSelectedObject() string {
+	if c == nil {
+		return ""
+	}
+	return c.cli_context.object.Name()
+}
+
+// GetSelectedList return the name of the object list selected by the last parse. Empty if none.
+func (c *ForjCli) GetSelectedList() string {
+	if c == nil || c.cli_context.list == nil {
+		return ""
+	}
+	return c.cli_context.list.name
+}
+
 // LoadContext gets data from context and store it in internal object model (ForjValue)
 //
 //
 func (c *ForjCli) loadContext(args []string, context interface{}) (err error) {
 	// First Parse cli context to load kingpin data with initial kingpin definition.
+	c.setAutoEnvars()
 	if v, err := c.App.ParseContext(args); err != nil && v.IsInvalidContext() {
 		return err
 	} else {
@@ -41,59 +67,15 @@ func (c *ForjCli) loadContext(args []string, context interface{}) (err error) {
 	c.identifyObjects(c.cur_cmds[len(c.cur_cmds)-1])
 
 	// Load object list instances from cli identified parameters
-	c.loadListData(nil, c.cli_context.context)
-
-	// Load Application/Action layer information (object => '_app'/'<app_name>'/...)
-	c.loadAppData()
-
-	// Load anything that could be required from any existing flags setup.
-	// Ex: app driver - app object hook. - Add new flags/args/objects
-	//     Settings of Defaults, flags attributes - Application hook. - Update existing flags.
-	// Context hook is started in following order
-	// - Application layer.
-	// - Object layer.
-	// - Object list layer.
-	var executed bool
-	if err, executed = c.contextHook(context); err != nil {
+	if err = c.loadContextListData(); err != nil {
 		return
-	} else {
-		if !executed { // No hook executed. So, no need to refresh the context. Just add list flags.
-			// Define instance flags for each list.
-			c.addInstanceFlags()
-		}
 	}
 
-	// Reparse context if hooks has created new list or objects or objects fields to become new recognized kingpin params.
-	if v, err := c.App.ParseContext(args); v == nil {
-		c.cur_cmds = []clier.CmdClauser{}
-		return err
-	} else {
-		c.cli_context.context = v
-	}
-
-	// Reload object list instances if hooks has created new list or objects or objects fields.
-	c.loadListData(nil, c.cli_context.context)
-
-	// ReLoad Application/Action layer information if hooks has added some of them at app/action layer.
+	// Load Application/Action layer information (object => '_app'/'<app_name>'/...)
 	c.loadAppData()
 
-	// Add instance flags for each object instances to each actions referring to those objects.
-	if !c.addInstanceFlags() { // No more flags added
-		return
-	}
-
-	// Reparse context if objects fields flags has been created.
-	if v, err := c.App.ParseContext(args); v == nil {
-		c.cur_cmds = []clier.CmdClauser{}
-		return err
-	} else {
-		c.cli_context.context = v
-	}
-
-	// and load their data.
-	c.loadListData(nil, c.cli_context.context)
-
-	return
+	// Execute hooks, add instance flags and reparse the context until nothing new is added.
+	return c.resolveContext(args, context)
 }
 
 // preload_objects do loading of objects with defaults in c.values[object].records["object"]
@@ -108,36 +90,23 @@ func (c *ForjCli) ContextHook(context interface{}) (error, bool) {
 // Load anything that could be required from any existing flags setup.
 // Ex: app driver - app object hook. - Add new flags/args/objects
 //     Settings of Defaults, flags attributes - Application hook. - Update existing flags.
-func (c *ForjCli) contextHook(context interface{}) (error, bool) {
+func (c *ForjCli) contextHook(data interface{}) (error, bool) {
 	var executed bool
-	if c.bef_ctx_hook != nil {
-		if err, status := c.bef_ctx_hook(c, context); err != nil {
+	if hook := c.beforeHook(); hook != nil {
+		if err, status := c.runHook("before", data, hook); err != nil {
 			return err, false
 		} else {
 			executed = status
 		}
 	}
 
-	for _, object := range c.objects {
-		for _, list := range object.list {
-			if list.context_hook == nil {
-				continue
-			}
-			if err, status := list.context_hook(list, c, context); err != nil {
-				object.err = err
-				return err, false
-			} else {
-				if status {
-					executed = true
-				}
-			}
-		}
-
-		if object.context_hook == nil {
-			continue
-		}
-		if err, status := object.context_hook(object, c, context); err != nil {
-			object.err = err
+	hooks, err := c.sortedHooks()
+	if err != nil {
+		return err, false
+	}
+	for _, hook := range hooks {
+		if err, status := hook.run(c, data); err != nil {
+			hook.object.err = err
 			return err, false
 		} else {
 			if status {
@@ -146,8 +115,8 @@ func (c *ForjCli) contextHook(context interface{}) (error, bool) {
 		}
 	}
 
-	if c.aft_ctx_hook != nil {
-		if err, status := c.aft_ctx_hook(c, context); err != nil {
+	if hook := c.afterHook(); hook != nil {
+		if err, status := c.runHook("after", data, hook); err != nil {
 			return err, false
 		} else {
 			if status {
@@ -159,6 +128,22 @@ func (c *ForjCli) contextHook(context interface{}) (error, bool) {
 	return nil, executed
 }
 
+// loadContextListData load object list instances from the cli context.
+//
+// Only field validation errors are reported. Other errors are traced, as the context may be incomplete until
+// hooks and instance flags are all loaded.
+func (c *ForjCli) loadContextListData() error {
+	var errs ForjErrors
+	for _, err := range GetErrors(c.loadListData(nil, c.cli_context.context)) {
+		if code := GetErrorCode(err); code == ErrValidationFailed || code == ErrMissingValue {
+			errs.Add(err)
+			continue
+		}
+		gotrace.Trace("Context list data partially loaded. %s", err)
+	}
+	return errs.ErrorOrNil()
+}
+
 // check List flag and start creating object instance.
 func (c *ForjCli) loadListData(more_flags func(*ForjCli), context clier.ParseContexter) error {
 	// check if the ObjectList is found.
@@ -179,26 +164,42 @@ func (c *ForjCli) loadListData(more_flags func(*ForjCli), context clier.ParseCon
 		}
 
 		key_name := l.obj.getKeyName()
-		// loop on list data to create object records.
+		// loop on list data to create object records. Every element is loaded to report all errors at once.
+		var errs ForjErrors
 		for _, attrs := range l.context {
 			// Get the list element key
 			key_value := attrs.Data[key_name]
 			if key_value == "" {
-				return fmt.Errorf("Invalid key value for object list '%s-%s'. a key cannot be empty.",
-					l.obj.name, l.name)
+				errs.Add(&ForjInvalidKeyError{
+					Object: l.obj.name,
+					List:   l.name,
+					Key:    key_name,
+					Reason: "a key cannot be empty.",
+				})
+				continue
 			}
 
 			data := c.setObjectAttributes(c.cli_context.action.name, l.obj.name, key_value)
+			if data == nil {
+				errs.Add(c.clearErr())
+				continue
+			}
 			for key, value := range attrs.Data {
 				field := l.obj.fields[key]
-				if _, err := data.set(field.value_type, key, value); err != nil {
-					return err
+				if err := field.checkValue(key_value, value, "list '"+l.name+"'"); err != nil {
+					errs.Add(err)
+					continue
+				}
+				origin := ForjValueOrigin{Source: SourceCli, Name: l.name, Raw: value, secret: field.isSecret()}
+				if _, err := data.setFrom(field.value_type, key, value, origin); err != nil {
+					errs.Add(err)
+					continue
 				}
-				data.attrs[key] = value
 			}
 		}
 		gotrace.Trace("Loading Data list from an Object list flags.")
-		return c.updateObjectFromContext(l.actions[c.cli_context.action.name].params)
+		errs.Add(c.updateObjectFromContext(l.actions[c.cli_context.action.name].params))
+		return errs.ErrorOrNil()
 	}
 
 	// Check if the Object is found
@@ -214,34 +215,69 @@ func (c *ForjCli) loadListData(more_flags func(*ForjCli), context clier.ParseCon
 			return fmt.Errorf("Unable to find key '%s' in object action '%s-%s' parameters.",
 				key_name, o.name, c.cli_context.action.name)
 		}
-		if v, found := c.getContextValue(context, param.(forjParam)); !found {
+		v, found := c.getContextValue(context, param.(forjParam))
+		key_value = to_string(v)
+		// A missing key is asked in interactive mode.
+		key_prompted := false
+		if key_value == "" && (c.IsInteractive() || paramOptions(param).IsPrompted()) {
+			answer, err := c.missingValue(o, "", o.fields[key_name], paramOptions(param))
+			if err != nil {
+				return err
+			}
+			key_value = answer
+			key_prompted = true
+		}
+		if !found && !key_prompted {
 			return fmt.Errorf("Unable to find key '%s' value from action '%s' parameters. "+
 				"Missing OnActions().AddFlag(%s)?", key_name, c.cli_context.action.name, key_name)
-		} else {
-			key_value = to_string(v)
 		}
 		if key_value == "" {
-			return fmt.Errorf("Invalid key value for object '%s'. a key cannot be empty.", o.name)
+			return &ForjInvalidKeyError{Object: o.name, Key: key_name, Reason: "a key cannot be empty."}
 		}
 		gotrace.Trace("New object record identified by key '%s' (%s).", key_value, o.getKeyName())
+		c.cli_context.instance = key_value
 
 		// Search for object list flags
-		if err := c.updateObjectFromContext(o.actions[c.cli_context.action.name].params); err != nil {
-			return err
-		}
+		var errs ForjErrors
+		errs.Add(c.updateObjectFromContext(o.actions[c.cli_context.action.name].params))
 
 		// get or create a record and populate it with all flags/args
 		data := c.setObjectAttributes(c.cli_context.action.name, o.name, key_value)
+		if data == nil {
+			errs.Add(c.clearErr())
+			return errs.ErrorOrNil()
+		}
 		for field_name, field := range o.fields {
 			param := o.actions[c.cli_context.action.name].params[field_name]
 			v, _ := c.getContextValue(context, param.(forjParam))
+			origin := paramOrigin(context, param, v)
+			if field_name == key_name && key_prompted {
+				v = key_value
+				origin = ForjValueOrigin{Source: SourcePrompt, Name: field_name, Raw: key_value, secret: field.isSecret()}
+			}
+			// A missing secret is asked after the secret provider, once parsed. (See loadProviderSecrets)
+			deferred := field.isSecret() && c.secret_provider != nil
+			if rawString(v) == "" && paramOptions(param).IsPrompted() && !deferred {
+				answer, err := c.missingValue(o, key_value, field, paramOptions(param))
+				if err != nil {
+					errs.Add(err)
+					continue
+				}
+				v = answer
+				origin = ForjValueOrigin{Source: SourcePrompt, Name: field_name, Raw: answer, secret: field.isSecret()}
+			}
+			if err := field.checkValue(key_value, v, origin.String()); err != nil {
+				errs.Add(err)
+				continue
+			}
 			// even if v is nil, a record is created. But will be considered as not found in Forj*.Get* functions
-			if _, err := data.set(field.value_type, field_name, v); err != nil {
-				return err
+			if _, err := data.setFrom(field.value_type, field_name, v, origin); err != nil {
+				errs.Add(err)
+				continue
 			}
 			param.forjParamUpdater().set_ref(data)
 		}
-		return nil
+		return errs.ErrorOrNil()
 	}
 
 	if c.cli_context.action == nil {
@@ -265,12 +301,11 @@ func (c *ForjCli) updateObjectFromContext(params map[string]ForjParam) error {
 			param.forjParamList().createObjectDataFromParams(params)
 		}
 	}
+	var errs ForjErrors
 	for _, obj := range objs {
-		if err := obj.createObjectDataFromParams(params); err != nil {
-			return err
-		}
+		errs.Add(obj.createObjectDataFromParams(params))
 	}
-	return nil
+	return errs.ErrorOrNil()
 }
 
 // Get the list of objects that are identified by all params list given.
@@ -291,10 +326,10 @@ func (c *ForjCli) getContextValue(context clier.ParseContexter, param forjParam)
 	switch param.(type) {
 	case *ForjArg:
 		a := param.(*ForjArg)
-		return context.GetArgValue(a.arg)
+		return a.GetContextValue(context)
 	case *ForjFlag:
 		f := param.(*ForjFlag)
-		return context.GetFlagValue(f.flag)
+		return f.GetContextValue(context)
 	}
 	return "", false
 }
@@ -403,19 +438,21 @@ func (c *ForjCli) loadObjectData() error {
 		a := c.cli_context.action
 		params = a.params
 	}
+	var errs ForjErrors
 	for _, param := range params {
 		if p, ok := param.(forjParamObject); ok {
 			if err := p.UpdateObject(); err != nil {
-				return fmt.Errorf("Unable to load Object data. %s", err)
+				errs.Add(err)
 			}
 		}
 	}
-	return nil
+	return errs.ErrorOrNil()
 }
 
 func (c *ForjCli) identifyObjects(cmd clier.CmdClauser) {
 	c.cli_context.action = nil
 	c.cli_context.object = nil
+	c.cli_context.instance = ""
 	c.cli_context.list = nil
 	// Identify in Actions, in Objects, then in ObjectList
 	for _, action := range c.actions {
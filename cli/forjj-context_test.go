@@ -625,7 +625,7 @@ func TestForjCli_loadListData_contextMultipleObjectList(t *testing.T) {
 }
 
 // TestForjCli_loadListData_contextObjectData :
-// check if <app> create test --flag "flag value" --flag2 "value"
+// check if <app> create test --flag "flag-value" --flag2 "value"
 // => creates 1 object 'test' record with key and all data set.
 func TestForjCli_loadListData_contextObjectData(t *testing.T) {
 	t.Log("Expect ForjCli_loadListData() to create object list instances.")
@@ -637,7 +637,7 @@ func TestForjCli_loadListData_contextObjectData(t *testing.T) {
 		test_help   = "test help"
 		flag        = "flag"
 		flag_help   = "flag help"
-		flag_value1 = "flag value"
+		flag_value1 = "flag-value"
 		flag2       = "flag2"
 		flag2_help  = "flag2 help"
 		flag_value2 = "other"
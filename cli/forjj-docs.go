@@ -0,0 +1,492 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// ForjDocPage is the documentation of one command of the application. Pages are built by ForjCli.DocPages.
+//
+// A page is rendered as Markdown with Markdown() or as a roff man page with Man().
+type ForjDocPage struct {
+	Command     []string         // Command path. Ex: [forjj create repo]
+	Help        string           // Command help
+	Object      string           // Object managed by the command. Empty for application and action pages.
+	Role        string           // Object role.
+	Args        []ForjDocParam   // Positional arguments
+	Flags       []ForjDocParam   // Flags
+	Lists       []ForjDocList    // Object lists syntax, given by list arguments or list flags.
+	Subcommands []ForjDocCommand // Commands under this one.
+}
+
+// ForjDocParam is the documentation of a flag or an argument.
+type ForjDocParam struct {
+	Name     string
+	Type     string
+	Help     string
+	Required bool
+	Default  string
+	Envar    string
+	Regexp   string   // Field value format.
+	Enum     []string // Accepted values.
+}
+
+// ForjDocList is the documentation of an object list syntax.
+type ForjDocList struct {
+	Name   string   // Argument or flag name
+	Object string   // Object listed
+	Sep    string   // Elements separator
+	Sample string   // Element syntax sample. Ex: [name:]url
+	Fields []string // Fields given by the element syntax.
+	Flags  []string // Instance fields which can be set with --<instance>-<field>
+}
+
+// ForjDocCommand is a reference to a sub command page.
+type ForjDocCommand struct {
+	Name string
+	Help string
+	Page string // Page name of the sub command.
+}
+
+// DocPages return the documentation pages of the application command tree.
+//
+// One page is built for the application, for each action, for each object action and for each object list action.
+// Pages are sorted by command path.
+func (c *ForjCli) DocPages() (pages []*ForjDocPage) {
+	if c == nil {
+		return nil
+	}
+	app_name := c.App.Name()
+	root := &ForjDocPage{
+		Command: []string{app_name},
+		Flags:   c.docParams(flagsParams(c.flags), false),
+	}
+	pages = append(pages, root)
+
+	for action_name, action := range c.actions {
+		page := &ForjDocPage{
+			Command: []string{app_name, action_name},
+			Help:    action.desc,
+			Flags:   c.docParams(action.params, false),
+			Args:    c.docParams(action.params, true),
+			Lists:   docLists(action.params),
+		}
+		root.Subcommands = append(root.Subcommands, page.command(action.desc))
+		pages = append(pages, page)
+
+		for object_name, o := range c.objects {
+			if oa, found := o.actions[action_name]; found {
+				pages = append(pages, page.addObjectPage(c, object_name, o, oa))
+			}
+			for _, l := range o.list {
+				if la, found := l.actions[action_name]; found {
+					pages = append(pages, page.addObjectPage(c, l.getParamListObjectName(), o, la))
+				}
+			}
+		}
+		sort.Sort(forjDocCommands(page.Subcommands))
+	}
+	sort.Sort(forjDocCommands(root.Subcommands))
+	sort.Sort(forjDocPages(pages))
+	return
+}
+
+// WriteDocs write all documentation pages in dir. Each page is written as <page>.md and as <page>.1 man page.
+//
+// Ex: forjj-create-repo.md and forjj-create-repo.1
+func (c *ForjCli) WriteDocs(dir string) error {
+	if c == nil {
+		return fmt.Errorf("Unable to write documentation. Cli is nil.")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("Unable to create documentation directory '%s'. %s", dir, err)
+	}
+	for _, page := range c.DocPages() {
+		for file, content := range map[string]string{
+			page.Name() + ".md": page.Markdown(),
+			page.Name() + ".1":  page.Man(),
+		} {
+			file = filepath.Join(dir, file)
+			if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+				return fmt.Errorf("Unable to write documentation page '%s'. %s", file, err)
+			}
+			gotrace.Trace("Documentation page '%s' written.", file)
+		}
+	}
+	return nil
+}
+
+// Name return the page name. Ex: forjj-create-repo
+func (p *ForjDocPage) Name() string {
+	return strings.Join(p.Command, "-")
+}
+
+// Usage return the command usage line. Ex: forjj create repo [flags] <name>
+func (p *ForjDocPage) Usage() string {
+	usage := strings.Join(p.Command, " ")
+	if len(p.Subcommands) > 0 {
+		usage += " <command>"
+	}
+	if len(p.Flags) > 0 {
+		usage += " [flags]"
+	}
+	for _, arg := range p.Args {
+		if arg.Required {
+			usage += " <" + arg.Name + ">"
+		} else {
+			usage += " [<" + arg.Name + ">]"
+		}
+	}
+	return usage
+}
+
+// Markdown return the page rendered as Markdown.
+func (p *ForjDocPage) Markdown() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s\n\n", strings.Join(p.Command, " "))
+	if p.Help != "" {
+		fmt.Fprintf(&b, "%s\n\n", p.Help)
+	}
+	if p.Object != "" {
+		fmt.Fprintf(&b, "Object: `%s`", p.Object)
+		if p.Role != "" {
+			fmt.Fprintf(&b, " (role: %s)", p.Role)
+		}
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "## Usage\n\n    %s\n\n", p.Usage())
+
+	for _, section := range []struct {
+		title  string
+		params []ForjDocParam
+		prefix string
+	}{
+		{"Arguments", p.Args, ""},
+		{"Flags", p.Flags, "--"},
+	} {
+		if len(section.params) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "## %s\n\n", section.title)
+		b.WriteString("| Name | Type | Required | Default | Envar | Format | Description |\n")
+		b.WriteString("|------|------|----------|---------|-------|--------|-------------|\n")
+		for _, param := range section.params {
+			fmt.Fprintf(&b, "| `%s%s` | %s | %s | %s | %s | %s | %s |\n",
+				section.prefix, param.Name, param.Type, docYesNo(param.Required),
+				docCode(param.Default), docCode(param.Envar), docCode(param.format()), mdEscape(param.Help))
+		}
+		b.WriteString("\n")
+	}
+
+	for _, list := range p.Lists {
+		fmt.Fprintf(&b, "## %s list syntax\n\n", list.Name)
+		b.WriteString(mdEscape(list.description()) + "\n\n")
+		if len(list.Flags) > 0 {
+			fmt.Fprintf(&b, "Each `%s` instance can be detailed with: %s\n\n", list.Object,
+				docCodeList("--<instance>-", list.Flags))
+		}
+	}
+
+	if len(p.Subcommands) > 0 {
+		b.WriteString("## Commands\n\n")
+		for _, cmd := range p.Subcommands {
+			fmt.Fprintf(&b, "- [%s](%s.md): %s\n", cmd.Name, cmd.Page, mdEscape(cmd.Help))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Man return the page rendered as a roff man page. (section 1)
+func (p *ForjDocPage) Man() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, ".TH \"%s\" \"1\" \"\" \"%s\" \"%s manual\"\n",
+		roffEscape(strings.ToUpper(p.Name())), roffEscape(p.Command[0]), roffEscape(p.Command[0]))
+	fmt.Fprintf(&b, ".SH NAME\n%s", roffEscape(p.Name()))
+	if p.Help != "" {
+		fmt.Fprintf(&b, " \\- %s", roffEscape(p.Help))
+	}
+	fmt.Fprintf(&b, "\n.SH SYNOPSIS\n.B %s\n", roffEscape(p.Usage()))
+	if p.Object != "" {
+		fmt.Fprintf(&b, ".SH DESCRIPTION\nObject \\fB%s\\fR", roffEscape(p.Object))
+		if p.Role != "" {
+			fmt.Fprintf(&b, " (role: %s)", roffEscape(p.Role))
+		}
+		b.WriteString(".\n")
+	}
+
+	for _, section := range []struct {
+		title  string
+		params []ForjDocParam
+		prefix string
+	}{
+		{"ARGUMENTS", p.Args, ""},
+		{"OPTIONS", p.Flags, "--"},
+	} {
+		if len(section.params) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, ".SH %s\n", section.title)
+		for _, param := range section.params {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR \\fI%s\\fR", roffEscape(section.prefix+param.Name), param.Type)
+			if param.Required {
+				b.WriteString(" (required)")
+			}
+			fmt.Fprintf(&b, "\n%s\n", roffEscape(param.Help))
+			for _, detail := range [][2]string{
+				{"Default", param.Default},
+				{"Environment", param.Envar},
+				{"Format", param.format()},
+			} {
+				if detail[1] != "" {
+					fmt.Fprintf(&b, ".br\n%s: %s\n", detail[0], roffEscape(detail[1]))
+				}
+			}
+		}
+	}
+
+	for _, list := range p.Lists {
+		fmt.Fprintf(&b, ".SH %s LIST SYNTAX\n%s\n", roffEscape(strings.ToUpper(list.Name)),
+			roffEscape(list.description()))
+		if len(list.Flags) > 0 {
+			fmt.Fprintf(&b, ".br\nEach %s instance can be detailed with: %s\n", roffEscape(list.Object),
+				roffEscape(strings.Join(prefixAll("--<instance>-", list.Flags), ", ")))
+		}
+	}
+
+	if len(p.Subcommands) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+		for _, cmd := range p.Subcommands {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(cmd.Name), roffEscape(cmd.Help))
+		}
+		b.WriteString(".SH SEE ALSO\n")
+		refs := make([]string, 0, len(p.Subcommands))
+		for _, cmd := range p.Subcommands {
+			refs = append(refs, "\\fB"+roffEscape(cmd.Page)+"\\fR(1)")
+		}
+		b.WriteString(strings.Join(refs, ", ") + "\n")
+	}
+	return b.String()
+}
+
+// command return the reference to this page, as a sub command.
+func (p *ForjDocPage) command(help string) ForjDocCommand {
+	return ForjDocCommand{Name: p.Command[len(p.Command)-1], Help: help, Page: p.Name()}
+}
+
+// addObjectPage create the object action page and reference it in the action page.
+func (p *ForjDocPage) addObjectPage(c *ForjCli, name string, o *ForjObject, oa *ForjObjectAction) *ForjDocPage {
+	page := &ForjDocPage{
+		Command: append(append([]string{}, p.Command...), name),
+		Help:    oa.help,
+		Object:  o.name,
+		Role:    o.role,
+		Flags:   c.docParams(oa.params, false),
+		Args:    c.docParams(oa.params, true),
+		Lists:   docLists(oa.params),
+	}
+	p.Subcommands = append(p.Subcommands, page.command(oa.help))
+	return page
+}
+
+// docParams return the sorted documentation of flags (args = false) or args (args = true).
+// Hidden params and instance flags (--<instance>-<field>) are not documented.
+func (c *ForjCli) docParams(params map[string]ForjParam, args bool) (docs []ForjDocParam) {
+	for name, p := range params {
+		var doc ForjDocParam
+		var options *ForjOpts
+		switch param := p.(type) {
+		case *ForjFlag:
+			if args || param.instance_name != "" {
+				continue
+			}
+			doc = ForjDocParam{Name: name, Type: param.value_type, Help: param.help, Envar: param.getEnvar()}
+			options = param.options
+			doc.Regexp = c.docRegexp(param.obj, param.list, param.field_name)
+		case *ForjArg:
+			if !args || param.instance_name != "" {
+				continue
+			}
+			doc = ForjDocParam{Name: name, Type: param.value_type, Help: param.help}
+			options = param.options
+			_, doc.Envar = options.HasEnvar()
+			doc.Regexp = c.docRegexp(param.obj, param.list, param.field_name)
+		case *ForjFlagList:
+			if args {
+				continue
+			}
+			doc = ForjDocParam{Name: name, Type: List, Help: param.help}
+		case *ForjArgList:
+			if !args {
+				continue
+			}
+			// The arg list help includes the list definition. The list section documents it.
+			doc = ForjDocParam{Name: name, Type: List, Help: param.obj.help, Required: true}
+		default:
+			continue
+		}
+		if options.isHidden() {
+			continue
+		}
+		if options != nil {
+			doc.Required = options.IsRequired()
+			doc.Default = options.declaredDefault()
+			doc.Enum = options.GetEnum()
+		}
+		docs = append(docs, doc)
+	}
+	sort.Sort(forjDocParams(docs))
+	return
+}
+
+// docLists return the documentation of object lists given by list flags or list arguments.
+func docLists(params map[string]ForjParam) (lists []ForjDocList) {
+	for name, p := range params {
+		var l *ForjObjectList
+		switch param := p.(type) {
+		case *ForjFlagList:
+			l = param.obj
+		case *ForjArgList:
+			l = param.obj
+		}
+		if l == nil || l.obj == nil {
+			continue
+		}
+		list := ForjDocList{Name: name, Object: l.obj.name, Sep: l.sep, Sample: l.sample}
+		for _, field_name := range l.fields_name {
+			list.Fields = append(list.Fields, field_name)
+		}
+		for field_name := range l.obj.fields {
+			if !l.isListField(field_name) {
+				list.Flags = append(list.Flags, field_name)
+			}
+		}
+		sort.Strings(list.Fields)
+		sort.Strings(list.Flags)
+		lists = append(lists, list)
+	}
+	sort.Sort(forjDocLists(lists))
+	return
+}
+
+// docRegexp return the regexp validating the object field value. Empty if none.
+func (c *ForjCli) docRegexp(o *ForjObject, l *ForjObjectList, field_name string) string {
+	if o == nil && l != nil {
+		o = l.obj
+	}
+	if o == nil || field_name == "" {
+		return ""
+	}
+	if f, found := o.fields[field_name]; found {
+		return c.buildCapture(f.regexp)
+	}
+	return ""
+}
+
+// flagsParams return application flags as a params collection.
+func flagsParams(flags map[string]*ForjFlag) map[string]ForjParam {
+	params := make(map[string]ForjParam)
+	for name, f := range flags {
+		params[name] = f
+	}
+	return params
+}
+
+// format return the value format documentation. Enum values or field regexp.
+func (d ForjDocParam) format() string {
+	if len(d.Enum) > 0 {
+		return strings.Join(d.Enum, ", ")
+	}
+	return d.Regexp
+}
+
+// description return the list syntax description.
+func (l ForjDocList) description() string {
+	ret := fmt.Sprintf("A list of %s separated by '%s'. Each element is given as '%s'", l.Object, l.Sep, l.Sample)
+	if len(l.Fields) > 0 {
+		ret += fmt.Sprintf(" and sets %s", strings.Join(l.Fields, ", "))
+	}
+	return ret + "."
+}
+
+// forjDocCommands sort commands by name.
+type forjDocCommands []ForjDocCommand
+
+func (s forjDocCommands) Len() int           { return len(s) }
+func (s forjDocCommands) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s forjDocCommands) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+// forjDocPages sort pages by command path.
+type forjDocPages []*ForjDocPage
+
+func (s forjDocPages) Len() int      { return len(s) }
+func (s forjDocPages) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s forjDocPages) Less(i, j int) bool {
+	return strings.Join(s[i].Command, " ") < strings.Join(s[j].Command, " ")
+}
+
+// forjDocParams sort params by name.
+type forjDocParams []ForjDocParam
+
+func (s forjDocParams) Len() int           { return len(s) }
+func (s forjDocParams) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s forjDocParams) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+// forjDocLists sort lists by name.
+type forjDocLists []ForjDocList
+
+func (s forjDocLists) Len() int           { return len(s) }
+func (s forjDocLists) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s forjDocLists) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+func docYesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// docCode return the value as Markdown code. Empty if value is empty.
+func docCode(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + strings.Replace(value, "|", "\\|", -1) + "`"
+}
+
+func docCodeList(prefix string, values []string) string {
+	ret := make([]string, 0, len(values))
+	for _, v := range prefixAll(prefix, values) {
+		ret = append(ret, docCode(v))
+	}
+	return strings.Join(ret, ", ")
+}
+
+func prefixAll(prefix string, values []string) []string {
+	ret := make([]string, 0, len(values))
+	for _, v := range values {
+		ret = append(ret, prefix+v)
+	}
+	return ret
+}
+
+// mdEscape escape Markdown table separators and line breaks.
+func mdEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+// roffEscape escape roff special characters. Lines are joined.
+func roffEscape(s string) string {
+	s = strings.NewReplacer("\\", "\\e", "-", "\\-", "\n", " ").Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = "\\&" + s
+	}
+	return s
+}
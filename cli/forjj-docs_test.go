@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_DocPages(t *testing.T) {
+	t.Log("Expect ForjCli_DocPages() to document the full command tree.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", Opts().Envar("FORJJ_DEBUG"))
+	c.AddAppFlag(String, "secret", "secret help", Opts().Hidden())
+
+	c.NewActions(create, "Create resources", "create %s", true)
+	c.NewObject("repo", "a repository", "infra").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", Opts().Default("My | title")).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repositories").
+		AddActions(create)
+	app := c.App.Name()
+
+	// --- Run the test ---
+	pages := c.DocPages()
+
+	// --- Start testing ---
+	names := make([]string, 0, len(pages))
+	for _, page := range pages {
+		names = append(names, page.Name())
+	}
+	if v := strings.Join(names, " "); v != app+" "+app+"-create "+app+"-create-repo "+app+"-create-repos" {
+		t.Errorf("Expected application, action, object and list pages. Got '%s'", v)
+		return
+	}
+
+	root := pages[0]
+	if len(root.Flags) != 1 || root.Flags[0].Name != "debug" || root.Flags[0].Envar != "FORJJ_DEBUG" {
+		t.Errorf("Expected only visible application flag 'debug' with its envar. Got %#v", root.Flags)
+	}
+	if len(root.Subcommands) != 1 || root.Subcommands[0].Page != app+"-create" ||
+		root.Subcommands[0].Help != "Create resources" {
+		t.Errorf("Expected 'create' sub command. Got %#v", root.Subcommands)
+	}
+
+	repo := pages[2]
+	if repo.Help != "create a repository" || repo.Object != "repo" || repo.Role != "infra" {
+		t.Errorf("Expected repo page help, object and role. Got '%s', '%s', '%s'", repo.Help, repo.Object, repo.Role)
+	}
+	if len(repo.Flags) != 3 {
+		t.Errorf("Expected 3 flags on repo page. Got %d", len(repo.Flags))
+		return
+	}
+	if f := repo.Flags[1]; f.Name != "name" || !f.Required || f.Regexp != "("+w_f+")" {
+		t.Errorf("Expected 'name' to be required with the key format. Got %#v", f)
+	}
+	if f := repo.Flags[2]; f.Name != "title" || f.Default != "My | title" {
+		t.Errorf("Expected 'title' default to be documented. Got %#v", f)
+	}
+	if f := repo.Flags[0]; f.format() != "github, gitlab" {
+		t.Errorf("Expected 'kind' format to be the enum values. Got '%s'", f.format())
+	}
+
+	repos := pages[3]
+	if len(repos.Args) != 1 || repos.Args[0].Name != "repos" || repos.Args[0].Type != List {
+		t.Errorf("Expected 'repos' list argument. Got %#v", repos.Args)
+	}
+	if len(repos.Lists) != 1 || repos.Lists[0].Sample != "name" || strings.Join(repos.Lists[0].Flags, ",") != "kind,title" {
+		t.Errorf("Expected 'repos' list syntax with instance flags. Got %#v", repos.Lists)
+	}
+}
+
+func TestForjDocPage_Markdown_Man(t *testing.T) {
+	t.Log("Expect ForjDocPage_Markdown() and ForjDocPage_Man() to render pages.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", Opts().Envar("FORJJ_DEBUG"))
+	c.AddAppFlag(String, "secret", "secret help", Opts().Hidden())
+
+	c.NewActions(create, "Create resources", "create %s", true)
+	c.NewObject("repo", "a repository", "infra").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", Opts().Default("My | title")).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repositories").
+		AddActions(create)
+	app := c.App.Name()
+	man_app := roffEscape(strings.ToUpper(app))
+	pages := c.DocPages()
+
+	// --- Run the test ---
+	md := pages[2].Markdown()
+	man := pages[2].Man()
+
+	// --- Start testing ---
+	for _, expected := range []string{
+		"# " + app + " create repo\n",
+		"    " + app + " create repo [flags]\n",
+		"| `--name` | string | yes |",
+		"| `--title` | string | no | `My \\| title` |",
+	} {
+		if !strings.Contains(md, expected) {
+			t.Errorf("Expected Markdown to contain '%s'. Got:\n%s", expected, md)
+		}
+	}
+	for _, expected := range []string{
+		".TH \"" + man_app + "\\-CREATE\\-REPO\" \"1\"",
+		".SH NAME\n" + roffEscape(app) + "\\-create\\-repo \\- create a repository\n",
+		".TP\n\\fB\\-\\-name\\fR \\fIstring\\fR (required)\n",
+		".br\nFormat: github, gitlab\n",
+	} {
+		if !strings.Contains(man, expected) {
+			t.Errorf("Expected man page to contain '%s'. Got:\n%s", expected, man)
+		}
+	}
+	if v := pages[1].Markdown(); !strings.Contains(v, "- [repos]("+app+"-create-repos.md): create repositories\n") {
+		t.Errorf("Expected action page to link the list page. Got:\n%s", v)
+	}
+}
+
+func TestForjCli_WriteDocs(t *testing.T) {
+	t.Log("Expect ForjCli_WriteDocs() to write one Markdown and one man page per command.")
+
+	// --- Setting test context ---
+	dir, err := ioutil.TempDir("", "forjj-cli-docs")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory. %s", err)
+	}
+	defer os.RemoveAll(dir)
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", Opts().Envar("FORJJ_DEBUG"))
+	c.AddAppFlag(String, "secret", "secret help", Opts().Hidden())
+
+	c.NewActions(create, "Create resources", "create %s", true)
+	c.NewObject("repo", "a repository", "infra").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", Opts().Default("My | title")).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repositories").
+		AddActions(create)
+
+	// --- Run the test ---
+	err = c.WriteDocs(dir)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected WriteDocs() to work. Got '%s'", err)
+		return
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 8 {
+		t.Errorf("Expected 8 files written. Got %d", len(files))
+	}
+	if _, err := os.Stat(filepath.Join(dir, c.App.Name()+"-create-repos.1")); err != nil {
+		t.Errorf("Expected the 'repos' man page to be written. %s", err)
+	}
+}
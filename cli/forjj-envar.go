@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// DefaultEnvarPrefix is the environment variable prefix used by AutoEnvar if none is given.
+const DefaultEnvarPrefix = "FORJJ"
+
+// envarSanitizer match any sequence of characters not accepted in an environment variable name.
+var envarSanitizer = regexp.MustCompile("[^A-Z0-9]+")
+
+// ForjEnvarCollision describes several params mapped to the same environment variable.
+type ForjEnvarCollision struct {
+	Envar  string
+	Params []string // Params identification. (<object>/<field> or <object>/<instance>/<field>)
+}
+
+func (ec *ForjEnvarCollision) String() string {
+	return fmt.Sprintf("Environment variable '%s' is used by %s", ec.Envar, strings.Join(ec.Params, ", "))
+}
+
+// AutoEnvar enable automatic environment variable naming for object and instance fields flags.
+//
+// Names are built as <PREFIX>_<OBJECT>_<FIELD> and <PREFIX>_<OBJECT>_<INSTANCE>_<FIELD>. Ex: FORJJ_REPO_MY_REPO_TITLE
+// Names are upper case. Any character other than a letter or a digit is replaced by '_'.
+// If prefix is empty, DefaultEnvarPrefix is used.
+//
+// An envar declared with ForjOpts.Envar is kept.
+func (c *ForjCli) AutoEnvar(prefix string) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	if prefix == "" {
+		prefix = DefaultEnvarPrefix
+	}
+	c.envar_prefix = envarSanitize(prefix)
+	c.envars = make(map[string]string)
+	c.envar_collisions = nil
+	return c
+}
+
+// GetEnvarCollisions return the list of environment variables mapped by several params.
+// Collisions are detected at parse time, when AutoEnvar is enabled.
+func (c *ForjCli) GetEnvarCollisions() []*ForjEnvarCollision {
+	if c == nil {
+		return nil
+	}
+	return c.envar_collisions
+}
+
+// autoEnvarName return the automatic environment variable name of an object field.
+// instance is empty for object fields.
+func (c *ForjCli) autoEnvarName(object, instance, field string) string {
+	parts := []string{c.envar_prefix, envarSanitize(object)}
+	if instance != "" {
+		parts = append(parts, envarSanitize(instance))
+	}
+	return strings.Join(append(parts, envarSanitize(field)), "_")
+}
+
+// envarSanitize convert a name to an environment variable name part.
+func envarSanitize(name string) string {
+	return strings.Trim(envarSanitizer.ReplaceAllString(strings.ToUpper(name), "_"), "_")
+}
+
+// setAutoEnvars set automatic environment variables on object fields flags.
+//
+// Single objects fields values are updated from their environment variable.
+// It is executed before each context parse, as hooks can add new flags.
+func (c *ForjCli) setAutoEnvars() {
+	if c.envar_prefix == "" {
+		return
+	}
+	for _, action := range c.actions {
+		c.setParamsAutoEnvars(action.params)
+	}
+	for _, o := range c.objects {
+		for _, action := range o.actions {
+			c.setParamsAutoEnvars(action.params)
+		}
+		if o.single {
+			c.setSingleObjectAutoEnvars(o)
+		}
+	}
+	for _, l := range c.list {
+		for _, action := range l.actions {
+			c.setParamsAutoEnvars(action.params)
+		}
+	}
+}
+
+// setParamsAutoEnvars set automatic environment variables on object fields flags of a params collection.
+func (c *ForjCli) setParamsAutoEnvars(params map[string]ForjParam) {
+	for _, p := range params {
+		f, ok := p.(*ForjFlag)
+		if !ok || f.obj == nil || f.field_name == "" {
+			continue
+		}
+		instance := f.instance_name
+		if f.obj.single {
+			instance = ""
+		}
+		owner := envarOwner(f.obj.name, instance, f.field_name)
+		envar := f.getEnvar()
+		if envar == "" {
+			envar = c.autoEnvarName(f.obj.name, instance, f.field_name)
+			f.auto_envar = envar
+			f.flag.Envar(envar)
+			gotrace.Trace("set flag %s automatic Envar '%s'", f.name, envar)
+		}
+		c.registerEnvar(envar, owner)
+	}
+}
+
+// setSingleObjectAutoEnvars set single object fields values from their automatic environment variable.
+//
+// Only values not set, set by a default or by this environment variable are updated.
+func (c *ForjCli) setSingleObjectAutoEnvars(o *ForjObject) {
+	for field_name, field := range o.fields {
+		if found, _ := field.options.HasEnvar(); found {
+			continue
+		}
+		envar := c.autoEnvarName(o.name, "", field_name)
+		c.registerEnvar(envar, envarOwner(o.name, "", field_name))
+
+		value, found := os.LookupEnv(envar)
+		if !found || value == "" {
+			continue
+		}
+		r, found := c.values[o.name]
+		if !found {
+			continue
+		}
+		data, found := r.records[o.name]
+		if !found {
+			continue
+		}
+		switch origin, _ := data.GetOrigin(field_name); origin.Source {
+		case "", SourceDefault:
+		case SourceEnvar: // Refreshed at each context parse.
+			if origin.Name != envar {
+				continue
+			}
+		default:
+			continue
+		}
+		origin := ForjValueOrigin{Source: SourceEnvar, Name: envar, Raw: value, secret: field.isSecret()}
+		if origin.secret {
+			v, err := resolveSecret(value)
+			if err != nil {
+				gotrace.Warning("Unable to set '%s/%s' from envar '%s'. %s", o.name, field_name, envar, err)
+				continue
+			}
+			value = v
+		}
+		var typed interface{} = value
+		if field.value_type == StringSlice || field.value_type == StringMap {
+			typed = clier.EnvarValues(value)
+		}
+		if _, err := data.setFrom(field.value_type, field_name, typed, origin); err != nil {
+			gotrace.Warning("Unable to set '%s/%s' from envar '%s'. %s", o.name, field_name, envar, err)
+		}
+	}
+}
+
+// registerEnvar register the param using an environment variable. A collision is reported if the environment
+// variable is already used by another param.
+func (c *ForjCli) registerEnvar(envar, owner string) {
+	current, found := c.envars[envar]
+	if !found {
+		c.envars[envar] = owner
+		return
+	}
+	if current == owner {
+		return
+	}
+	for _, collision := range c.envar_collisions {
+		if collision.Envar != envar {
+			continue
+		}
+		for _, param := range collision.Params {
+			if param == owner {
+				return
+			}
+		}
+		collision.Params = append(collision.Params, owner)
+		sort.Strings(collision.Params)
+		gotrace.Warning("%s", collision)
+		return
+	}
+	collision := &ForjEnvarCollision{Envar: envar, Params: []string{current, owner}}
+	sort.Strings(collision.Params)
+	c.envar_collisions = append(c.envar_collisions, collision)
+	gotrace.Warning("%s", collision)
+}
+
+// envarOwner return the param identification used in collisions reports.
+func envarOwner(object, instance, field string) string {
+	if instance == "" {
+		return object + "/" + field
+	}
+	return object + "/" + instance + "/" + field
+}
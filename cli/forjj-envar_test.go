@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"os"
+	"testing"
+)
+
+func TestEnvarSanitize(t *testing.T) {
+	t.Log("Expect envarSanitize() to return valid environment variable name parts.")
+
+	tests := map[string]string{
+		"repo":        "REPO",
+		"my-repo.1":   "MY_REPO_1",
+		"--a__b--":    "A_B",
+		"Infra Repo!": "INFRA_REPO",
+	}
+	for name, expected := range tests {
+		// --- Run the test ---
+		v := envarSanitize(name)
+
+		// --- Start testing ---
+		if v != expected {
+			t.Errorf("Expected '%s' to be sanitized to '%s'. Got '%s'", name, expected, v)
+		}
+	}
+}
+
+func TestForjCli_AutoEnvar(t *testing.T) {
+	t.Log("Expect ForjCli_AutoEnvar() to set envars on object and instance fields flags.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd   = "cmd:"
+		c_repo  = "repo"
+		c_repos = "repos"
+		envar   = "TEST_WORKSPACE_PATH"
+	)
+	os.Setenv(envar, "/auto/ws")
+	defer os.Unsetenv(envar)
+
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AddFieldListCapture("w", w_f)
+	c.AutoEnvar("test")
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject(workspace, "workspace help", "").Single().
+		AddField(String, "path", "path help", "", Opts().Default("/default/ws"))
+	if c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "owner", "owner help", "", Opts().Envar("REPO_OWNER")).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("owner", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create) == nil {
+		t.Errorf("Expected object declaration to work. %s", c.GetObject(c_repo).Error())
+		return
+	}
+	c.OnActions(update).AddActionFlagFromObjectListAction(c_repo, "to_create", create)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + update, c_repos, "my-repo,repo2"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if f := app.GetFlag(create, c_repo, "title"); f == nil || !f.IsEnvar("TEST_REPO_TITLE") {
+		t.Error("Expected object flag 'title' envar to be 'TEST_REPO_TITLE'.")
+	}
+	if f := app.GetFlag(create, c_repo, "owner"); f == nil || !f.IsEnvar("REPO_OWNER") {
+		t.Error("Expected declared envar 'REPO_OWNER' to be kept.")
+	}
+	if f := app.GetFlag(update, "my-repo-title"); f == nil || !f.IsEnvar("TEST_REPO_MY_REPO_TITLE") {
+		t.Error("Expected instance flag 'my-repo-title' envar to be 'TEST_REPO_MY_REPO_TITLE'.")
+	}
+	if f := app.GetFlag(update, "my-repo-owner"); f == nil || !f.IsEnvar("MY-REPO_REPO_OWNER") {
+		t.Error("Expected instance flag 'my-repo-owner' to keep the declared envar prefixed by the instance name.")
+	}
+	if v := c.GetEnvarCollisions(); len(v) != 0 {
+		t.Errorf("Expected no envar collision. Got %s", v)
+	}
+	if v, _, _, _ := c.GetStringValue(workspace, workspace, "path"); v != "/auto/ws" {
+		t.Errorf("Expected workspace path to be set from '%s'. Got '%s'", envar, v)
+	}
+	if o, _, _ := c.GetValueOrigin(workspace, workspace, "path"); o.String() != "envar '"+envar+"'" {
+		t.Errorf("Expected workspace path origin to be the envar. Got '%s'", o)
+	}
+}
+
+func TestForjCli_GetEnvarCollisions(t *testing.T) {
+	t.Log("Expect ForjCli_GetEnvarCollisions() to report params mapped to the same envar.")
+
+	// --- Setting test context ---
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+	c.AutoEnvar("")
+
+	c.NewActions(create, create_help, "", false)
+	c.NewObject("my-repo", "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(String, "x", "x help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("x", nil)
+	c.NewObject("my", "my help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(String, "repo_x", "x help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("repo_x", nil)
+
+	// --- Run the test ---
+	c.Parse([]string{"cmd:" + create, "cmd:my", "name", "test"}, nil)
+
+	// --- Start testing ---
+	collisions := c.GetEnvarCollisions()
+	if len(collisions) != 1 {
+		t.Errorf("Expected 1 envar collision. Got %d", len(collisions))
+		return
+	}
+	if v := collisions[0]; v.Envar != "FORJJ_MY_REPO_X" || len(v.Params) != 2 ||
+		v.Params[0] != "my-repo/x" || v.Params[1] != "my/repo_x" {
+		t.Errorf("Expected 'FORJJ_MY_REPO_X' collision between 'my-repo/x' and 'my/repo_x'. Got '%s'", v)
+	}
+}
@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// ForjErrorCode identifies the kind of a cli error. See GetErrorCode.
+type ForjErrorCode string
+
+// Cli error codes.
+const (
+	ErrUnknownObject     ForjErrorCode = "UnknownObject"
+	ErrUnknownAction     ForjErrorCode = "UnknownAction"
+	ErrUnknownInstance   ForjErrorCode = "UnknownInstance"
+	ErrUnknownField      ForjErrorCode = "UnknownField"
+	ErrUnknownArg        ForjErrorCode = "UnknownArg"
+	ErrInvalidKey        ForjErrorCode = "InvalidKey"
+	ErrValidationFailed  ForjErrorCode = "ValidationFailed"
+	ErrConflictingAction ForjErrorCode = "ConflictingAction"
+	ErrHookFailed        ForjErrorCode = "HookFailed"
+	ErrMissingValue      ForjErrorCode = "MissingValue"
+)
+
+// ForjError is implemented by typed cli errors.
+type ForjError interface {
+	error
+	Code() ForjErrorCode
+}
+
+// GetErrorCode return the code of a typed cli error. Hints are ignored. (See ForjSuggestError)
+//
+// It returns an empty code for other errors.
+func GetErrorCode(err error) ForjErrorCode {
+	if e, ok := err.(*ForjSuggestError); ok {
+		err = e.err
+	}
+	if e, ok := err.(ForjError); ok {
+		return e.Code()
+	}
+	return ""
+}
+
+// ForjUnknownObjectError is returned when an object or object list is not defined.
+type ForjUnknownObjectError struct {
+	Object string // Object name
+	List   string // Object list name (<object>_<list>), if an object list was searched.
+}
+
+func (e *ForjUnknownObjectError) Error() string {
+	if e.List != "" {
+		return fmt.Sprintf("Unable to find object list '%s'.", e.List)
+	}
+	return fmt.Sprintf("Unable to find object '%s'.", e.Object)
+}
+
+func (e *ForjUnknownObjectError) Code() ForjErrorCode {
+	return ErrUnknownObject
+}
+
+// ForjUnknownActionError is returned when an action is not defined, or not defined for an object.
+type ForjUnknownActionError struct {
+	Action string // Action name
+	Object string // Object (or object list) name. Empty for an application action.
+}
+
+func (e *ForjUnknownActionError) Error() string {
+	if e.Object != "" {
+		return fmt.Sprintf("Unable to find action '%s' from object '%s'.", e.Action, e.Object)
+	}
+	return fmt.Sprintf("Unable to find action '%s'.", e.Action)
+}
+
+func (e *ForjUnknownActionError) Code() ForjErrorCode {
+	return ErrUnknownAction
+}
+
+// ForjUnknownInstanceError is returned when an object instance is not declared.
+type ForjUnknownInstanceError struct {
+	Object   string // Object name
+	Instance string // Object instance name (record key)
+}
+
+func (e *ForjUnknownInstanceError) Error() string {
+	return fmt.Sprintf("Instance '%s' is not found in object '%s'.", e.Instance, e.Object)
+}
+
+func (e *ForjUnknownInstanceError) Code() ForjErrorCode {
+	return ErrUnknownInstance
+}
+
+// ForjUnknownFieldError is returned when an object field is not defined.
+type ForjUnknownFieldError struct {
+	Object string // Object name
+	Field  string // Field name
+}
+
+func (e *ForjUnknownFieldError) Error() string {
+	return fmt.Sprintf("Unable to find '%s' field in Object '%s'.", e.Field, e.Object)
+}
+
+func (e *ForjUnknownFieldError) Code() ForjErrorCode {
+	return ErrUnknownField
+}
+
+// ForjUnknownArgError is returned when the command line contains an unknown action, object or flag.
+type ForjUnknownArgError struct {
+	Arg      string // Unknown command line word
+	Position int    // Word position in the command line, starting at 0 after the application name.
+	Err      error  // Parser error
+}
+
+func (e *ForjUnknownArgError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ForjUnknownArgError) Code() ForjErrorCode {
+	return ErrUnknownArg
+}
+
+// Unwrap return the parser error.
+func (e *ForjUnknownArgError) Unwrap() error {
+	return e.Err
+}
+
+// ForjInvalidKeyError is returned when an object instance key value is invalid.
+type ForjInvalidKeyError struct {
+	Object string // Object name
+	List   string // Object list name, if the key comes from an object list.
+	Key    string // Key field name
+	Reason string
+}
+
+func (e *ForjInvalidKeyError) Error() string {
+	if e.List != "" {
+		return fmt.Sprintf("Invalid key value for object list '%s-%s'. %s", e.Object, e.List, e.Reason)
+	}
+	return fmt.Sprintf("Invalid key value for object '%s'. %s", e.Object, e.Reason)
+}
+
+func (e *ForjInvalidKeyError) Code() ForjErrorCode {
+	return ErrInvalidKey
+}
+
+// Code identifies a field validation error.
+func (e *ForjFieldValidationError) Code() ForjErrorCode {
+	return ErrValidationFailed
+}
+
+// ForjConflictingActionError is returned when an object instance is requested by 2 different actions.
+type ForjConflictingActionError struct {
+	Object   string // Object name
+	Instance string // Object instance name (record key)
+	Action   string // Action requested
+	Current  string // Action already set on the instance
+}
+
+func (e *ForjConflictingActionError) Error() string {
+	return fmt.Sprintf("Unable to %s AND %s attribute at the same time. "+
+		"Please remove %s to one of the 2 different action and retry", e.Current, e.Action, e.Object)
+}
+
+func (e *ForjConflictingActionError) Code() ForjErrorCode {
+	return ErrConflictingAction
+}
+
+// ForjHookError is returned when a parse hook fails or can not be started. (parse context cancelled)
+//
+// The hook error message is kept as is.
+type ForjHookError struct {
+	Hook      string // Hook name. 'before', 'after', <object> or <object>/<list>
+	Pass      int    // Context resolution pass.
+	Cancelled bool   // true if the hook was not started.
+	Err       error  // Hook error
+}
+
+func (e *ForjHookError) Error() string {
+	if e.Cancelled {
+		return fmt.Sprintf("Parse hook '%s' not started. %s", e.Hook, e.Err)
+	}
+	return e.Err.Error()
+}
+
+func (e *ForjHookError) Code() ForjErrorCode {
+	return ErrHookFailed
+}
+
+// Unwrap return the hook error.
+func (e *ForjHookError) Unwrap() error {
+	return e.Err
+}
+
+// ForjMissingValueError is returned when a required object key or prompted field value is missing, and can not be
+// asked. (See ForjCli.Interactive)
+type ForjMissingValueError struct {
+	Object   string // Object name
+	Instance string // Object instance name. Empty if the key is missing.
+	Field    string // Field name
+	Err      error  // Prompt error, if the value could not be asked.
+}
+
+func (e *ForjMissingValueError) Error() string {
+	ret := fmt.Sprintf("Missing '%s' value for object '%s'.", e.Field, e.Object)
+	if e.Instance != "" {
+		ret = fmt.Sprintf("Missing '%s' value for object '%s' instance '%s'.", e.Field, e.Object, e.Instance)
+	}
+	if e.Err != nil {
+		ret += " " + e.Err.Error()
+	}
+	return ret
+}
+
+func (e *ForjMissingValueError) Code() ForjErrorCode {
+	return ErrMissingValue
+}
+
+// Unwrap return the prompt error, if any.
+func (e *ForjMissingValueError) Unwrap() error {
+	return e.Err
+}
+
+// ForjErrors aggregates errors found by one task, like Parse, so that all of them can be fixed in one go.
+type ForjErrors []error
+
+func (e ForjErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	ret := new(bytes.Buffer)
+	fmt.Fprintf(ret, "%d errors found:", len(e))
+	for _, err := range e {
+		fmt.Fprintf(ret, "\n- %s", err)
+	}
+	return ret.String()
+}
+
+// Add an error to the list. nil errors are ignored and aggregated errors are flattened.
+// An error already reported (same type and same data) is ignored.
+func (e *ForjErrors) Add(err error) {
+	if err == nil {
+		return
+	}
+	if errs, ok := err.(ForjErrors); ok {
+		for _, err := range errs {
+			e.Add(err)
+		}
+		return
+	}
+	for _, reported := range *e {
+		if reflect.DeepEqual(reported, err) {
+			return
+		}
+	}
+	*e = append(*e, err)
+}
+
+// ErrorOrNil return nil if there is no error, the error if only one, or the aggregated errors.
+func (e ForjErrors) ErrorOrNil() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e
+}
+
+// GetErrors return the list of errors aggregated in err. A single error is returned as a list of one.
+func GetErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if errs, ok := err.(ForjErrors); ok {
+		return errs
+	}
+	return []error{err}
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"fmt"
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjErrors_Add(t *testing.T) {
+	t.Log("Expect ForjErrors_Add() to ignore nil errors and flatten aggregated errors.")
+
+	// --- Setting test context ---
+	var errs ForjErrors
+	err1 := fmt.Errorf("error 1.")
+	err2 := &ForjUnknownObjectError{Object: "repo"}
+
+	// --- Run the test ---
+	errs.Add(nil)
+	if err := errs.ErrorOrNil(); err != nil {
+		t.Errorf("Expected no error. Got '%s'", err)
+	}
+	errs.Add(err1)
+	if err := errs.ErrorOrNil(); err != err1 {
+		t.Errorf("Expected the single error to be returned. Got '%s'", err)
+	}
+	errs.Add(ForjErrors{err2})
+
+	// --- Start testing ---
+	err := errs.ErrorOrNil()
+	if v := len(GetErrors(err)); v != 2 {
+		t.Errorf("Expected 2 errors. Got %d", v)
+	}
+	if v := err.Error(); v != "2 errors found:\n- error 1.\n- Unable to find object 'repo'." {
+		t.Errorf("Expected all errors to be reported. Got '%s'", v)
+	}
+}
+
+func TestGetErrorCode(t *testing.T) {
+	t.Log("Expect GetErrorCode() to identify typed errors, with or without hints.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		DefineActions(create)
+
+	// --- Run the test ---
+	_, err_object := c.getObject("repos")
+	_, _, err_action := c.getObjectAction("repo", "update")
+	_, _, err_getter := c.getValue("unknown", "key", "name")
+	c.WithObjectInstance("repo", "unknown")
+	err_instance := c.GetObject("repo").Error()
+
+	// --- Start testing ---
+	tests := []struct {
+		err      error
+		expected ForjErrorCode
+	}{
+		{err_object, ErrUnknownObject},
+		{err_action, ErrUnknownAction},
+		{err_getter, ErrUnknownObject},
+		{err_instance, ErrUnknownInstance},
+		{fmt.Errorf("untyped."), ""},
+	}
+	for _, test := range tests {
+		if v := GetErrorCode(test.err); v != test.expected {
+			t.Errorf("Expected error '%s' code to be '%s'. Got '%s'", test.err, test.expected, v)
+		}
+	}
+	if e, ok := err_instance.(*ForjUnknownInstanceError); !ok || e.Object != "repo" || e.Instance != "unknown" {
+		t.Errorf("Expected a ForjUnknownInstanceError on 'repo/unknown'. Got '%#v'", err_instance)
+	}
+}
+
+func TestForjErrors_Unwrap(t *testing.T) {
+	t.Log("Expect typed errors holding a cause to return it with Unwrap().")
+
+	// --- Setting test context ---
+	cause := fmt.Errorf("cause.")
+	tests := []error{
+		&ForjUnknownArgError{Arg: "unknown", Err: cause},
+		&ForjHookError{Hook: "before", Err: cause},
+		&ForjMissingValueError{Object: "repo", Field: "name", Err: cause},
+		withHints(cause, "unknown", []string{"known"}),
+	}
+
+	for _, err := range tests {
+		// --- Run the test ---
+		u, ok := err.(interface {
+			Unwrap() error
+		})
+
+		// --- Start testing ---
+		if !ok || u.Unwrap() != cause {
+			t.Errorf("Expected Unwrap() to return the cause. Got '%#v'", err)
+		}
+	}
+}
+
+func TestForjCli_setErr(t *testing.T) {
+	t.Log("Expect ForjCli_setErr() and ForjObject_setErr() to aggregate all errors.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	o := c.NewObject("repo", "repo help", "")
+
+	// --- Run the test ---
+	c.setErr("error %d.", 1)
+	c.setError(&ForjUnknownObjectError{Object: "repo"})
+	c.setErr("error %d.", 1)
+	o.setErr("error %d.", 2)
+	o.setError(&ForjUnknownFieldError{Object: "repo", Field: "title"})
+
+	// --- Start testing ---
+	if v := len(GetErrors(c.clearErr())); v != 2 {
+		t.Errorf("Expected 2 cli errors. Got %d", v)
+	}
+	if c.err != nil {
+		t.Errorf("Expected cli errors to be cleared. Got '%s'", c.err)
+	}
+	errs := GetErrors(o.clearErr())
+	if len(errs) != 2 || GetErrorCode(errs[1]) != ErrUnknownField {
+		t.Errorf("Expected 2 object errors. Got %v", errs)
+	}
+}
+
+func TestForjCli_Parse_AllErrors(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to report all invalid values at once.")
+
+	// --- Setting test context ---
+	const (
+		c_test  = "test"
+		c_flag  = "flag"
+		c_flag2 = "flag2"
+		c_flag3 = "flag3"
+		c_cmd   = "cmd:"
+	)
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(c_test, "test help", "").
+		AddKey(String, c_flag, "flag help", "[a-z]+", nil).
+		AddField(String, c_flag2, "flag2 help", "[0-9]+", nil).
+		AddField(String, c_flag3, "flag3 help", "[0-9]+", nil).
+		DefineActions(create).OnActions().
+		AddFlag(c_flag, Opts().Required()).
+		AddFlag(c_flag2, nil).
+		AddFlag(c_flag3, nil)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_test, c_flag, "value", c_flag2, "abc", c_flag3, "def"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail. Got no error.")
+		return
+	}
+	errs := GetErrors(err)
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 errors. Got %d: '%s'", len(errs), err)
+		return
+	}
+	fields := make([]string, 0, 2)
+	for _, e := range errs {
+		if verr, ok := e.(*ForjFieldValidationError); !ok {
+			t.Errorf("Expected a ForjFieldValidationError. Got '%s'", e)
+		} else {
+			fields = append(fields, verr.Field)
+		}
+	}
+	if v := strings.Join(fields, " "); v != c_flag2+" "+c_flag3 && v != c_flag3+" "+c_flag2 {
+		t.Errorf("Expected errors on fields '%s' and '%s'. Got '%s'", c_flag2, c_flag3, v)
+	}
+}
+
+func TestForjCli_loadListData_AllErrors(t *testing.T) {
+	t.Log("Expect ForjCli_loadListData() to report all conflicting list elements.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+
+	l := c.GetObject("repo").list["to_create"]
+	c.cli_context.action = c.actions[create]
+	c.cli_context.object = l.obj
+	c.cli_context.list = l
+	c.setObjectAttributes(update, "repo", "a")
+	c.setObjectAttributes(update, "repo", "b")
+	l.Set("a,b,c")
+
+	// --- Run the test ---
+	err := c.loadListData(nil, nil)
+
+	// --- Start testing ---
+	if v := len(GetErrors(err)); v != 2 {
+		t.Errorf("Expected 2 errors. Got %d: '%s'", v, err)
+	}
+	for _, e := range GetErrors(err) {
+		if v, ok := e.(*ForjConflictingActionError); !ok {
+			t.Errorf("Expected a conflicting action error. Got '%s'", e)
+		} else if v.Current != update || v.Action != create {
+			t.Errorf("Expected '%s' to conflict with '%s'. Got %#v", create, update, v)
+		}
+	}
+	if _, found := c.values["repo"].records["c"]; !found {
+		t.Error("Expected the valid 'c' element to be loaded.")
+	}
+}
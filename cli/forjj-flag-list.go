@@ -232,6 +232,7 @@ func (f *ForjFlagList) createObjectDataFromParams(params map[string]ForjParam) e
 		}
 		for key, attr := range list_data.Data {
 			data.attrs[key] = attr
+			data.origins[key] = ForjValueOrigin{Source: SourceCli, Name: f.name, Raw: attr}
 		}
 	}
 	return nil
@@ -26,6 +26,7 @@ type ForjFlag struct {
 	instance_name string          // List/object related: Instance name where this flag is attached.
 	field_name    string          // List/object related: Field name where this flag is attached
 	data          *ForjData       // Data set from this flag.
+	auto_envar    string          // Environment variable name given by ForjCli.AutoEnvar. Empty if not set.
 }
 
 // NewForjFlag creates ForjFlag object from a flagClauser
@@ -64,20 +65,15 @@ func (f *ForjFlag) set_cmd(cmd clier.CmdClauser, paramIntType, name, help string
 		}
 
 	}
+	// Value type must be set before options. Repeatable flags defaults depend on it.
+	f.flagv = flagValue(f.flag, paramIntType, f.options)
 	f.set_options(options)
-
-	switch paramIntType {
-	case String:
-		f.flagv = f.flag.String()
-	case Bool:
-		f.flagv = f.flag.Bool()
-	}
 	gotrace.Trace("kingping.Arg '%s' added to '%s'", name, cmd.FullCommand())
 }
 
 func (f *ForjFlag) loadFrom(context clier.ParseContexter) {
-	if v, found := context.GetFlagValue(f.flag); found {
-		copyValue(f.flagv, v)
+	if v, found := f.GetContextValue(context); found {
+		copyValue(v, f.flagv)
 		f.found = true
 	} else {
 		f.found = false
@@ -96,12 +92,15 @@ func (f *ForjFlag) set_options(options *ForjOpts) {
 		return
 	}
 
-	if v, ok := options.opts["required"]; ok && to_bool(v) {
+	// A prompted value is checked by the cli. See ForjCli.Interactive
+	if v, ok := options.opts["required"]; ok && to_bool(v) && !options.IsPrompted() {
 		gotrace.Trace("set flag %s as Required", f.name)
 		f.flag.Required()
 	}
 
-	if v, ok := options.opts["default"]; ok {
+	if v, ok := options.opts["default"]; ok && options.IsSecret() {
+		gotrace.Warning("Secret flag %s default value ignored.", f.name)
+	} else if ok {
 		gotrace.Trace("set flag %s default to '%s'", f.name, to_string(v))
 		f.flag.Default(to_string(v))
 	}
@@ -111,11 +110,8 @@ func (f *ForjFlag) set_options(options *ForjOpts) {
 		f.flag.Hidden()
 	}
 
-	if v, ok := options.opts["envar"]; ok {
-		envar := to_string(v)
-		if f.instance_name != "" {
-			envar = strings.ToUpper(f.instance_name) + "_" + to_string(v)
-		}
+	if _, ok := options.opts["envar"]; ok {
+		envar := f.envarName(options)
 		gotrace.Trace("set flag %s Envar '%s'", f.name, envar)
 		f.flag.Envar(envar)
 	}
@@ -126,6 +122,30 @@ func (f *ForjFlag) set_options(options *ForjOpts) {
 	}
 }
 
+// envarName return the flag environment variable name from options. Instance flags envar are prefixed
+// by the instance name.
+func (f *ForjFlag) envarName(options *ForjOpts) (envar string) {
+	found, v := options.HasEnvar()
+	if !found {
+		return
+	}
+	envar = v
+	if f.instance_name != "" {
+		envar = strings.ToUpper(f.instance_name) + "_" + v
+	}
+	return
+}
+
+// getEnvar return the environment variable name attached to the flag. Empty if none.
+//
+// The automatic envar name is used if none has been declared in options.
+func (f *ForjFlag) getEnvar() string {
+	if envar := f.envarName(f.options); envar != "" {
+		return envar
+	}
+	return f.auto_envar
+}
+
 func (f *ForjFlag) GetBoolValue() bool {
 	return to_bool(f.flagv)
 }
@@ -148,10 +168,21 @@ func (f *ForjFlag) GetStringAddr() *string {
 	return nil
 }
 
+// GetContextValue return the flag value found in the parse context.
+//
+// A secret reference is not resolved. Secrets are read once, after the parse. (See ForjOpts.Secret)
 func (f *ForjFlag) GetContextValue(context clier.ParseContexter) (interface{}, bool) {
 	return context.GetFlagValue(f.flag)
 }
 
+// getContextSource return the source of the flag value found in the parse context. (See clier.ParseContexter)
+func (f *ForjFlag) getContextSource(context clier.ParseContexter) string {
+	if context == nil {
+		return ""
+	}
+	return context.GetFlagSource(f.flag)
+}
+
 func (f *ForjFlag) IsList() bool {
 	return false
 }
@@ -246,25 +277,20 @@ func (f *ForjFlag) UpdateObject() error {
 }
 
 func (f *ForjFlag) updateObject(c *ForjCli, object_name string) error {
-	var value interface{}
-
 	_, found, _, _ := c.GetStringValue(object_name, f.instance_name, f.field_name)
 
-	switch f.flagv.(type) {
-	case *string:
-		value = *f.flagv.(*string)
-		if value.(string) == "" && !found {
-			return nil
-		}
-	case *bool:
-		value = *f.flagv.(*bool)
-		if !value.(bool) && !found {
-			return nil
-		}
-	default:
+	value, zero, ok := deref_value(f.flagv)
+	if !ok {
 		return fmt.Errorf("Unable to convert flagv to object attribute value.")
 	}
-	return c.SetValue(object_name, f.instance_name, f.value_type, f.field_name, value)
+	if zero && !found {
+		return nil
+	}
+	if c.keepFileValue(object_name, f.instance_name, f.field_name, f) {
+		return nil
+	}
+	origin := c.updatedValueOrigin(object_name, f.instance_name, f.field_name, f, value)
+	return c.SetValueFrom(object_name, f.instance_name, f.value_type, f.field_name, value, origin)
 }
 
 func (f *ForjFlag) forjParam() (p forjParam) {
@@ -371,7 +397,7 @@ func (f *ForjFlag) updateContextData() {
 	}
 	ctxt := f.obj.cli.cli_context.context
 	if v, found := f.GetContextValue(ctxt); found {
-		f.data.set(f.value_type, f.field_name, v)
+		f.data.setFrom(f.value_type, f.field_name, v, paramOrigin(ctxt, f, v))
 	}
 }
 
@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// HookFunc is a cli parse hook. See OnBeforeParse and OnAfterParse.
+type HookFunc func(*HookContext) error
+
+// ObjectHookFunc is an object parse hook. See ForjObject.OnParse.
+type ObjectHookFunc func(*ForjObject, *HookContext) error
+
+// ListHookFunc is an object list parse hook. See ForjObjectList.OnParse.
+type ListHookFunc func(*ForjObjectList, *HookContext) error
+
+// HookLogger is the logger given to hooks.
+type HookLogger interface {
+	Trace(format string, a ...interface{})
+	Warning(format string, a ...interface{})
+	Error(format string, a ...interface{})
+}
+
+// HookContext is given to parse hooks.
+//
+// It embeds the parse context.Context. A hook doing long tasks should stop when Done() is closed.
+// A hook which updates existing definitions (Ex: a flag default value) must call Changed(). New objects, fields or
+// flags are detected without it.
+//
+// Hooks are executed again on each context resolution pass, until nothing new is defined and none of them reports a
+// change. So a hook must be idempotent: it must not add again what it already added, nor call Changed() if nothing
+// has been updated.
+// Hooks set by ParseHook, ParseBeforeHook or ParseAfterHook are executed once per parse. Their returned status means
+// 'executed' and is not a change report.
+type HookContext struct {
+	context.Context
+	Cli    *ForjCli
+	Pass   int             // Context resolution pass. (starts at 1, 0 out of context resolution)
+	Action *ForjAction     // Selected action. nil if none.
+	Object *ForjObject     // Selected object. nil if none.
+	List   *ForjObjectList // Selected object list. nil if none.
+	Data   interface{}     // Data given to Parse.
+	Log    HookLogger      // Logger. Messages are prefixed by the hook name.
+
+	name     string // Hook name
+	changed  bool
+	executed bool // Status returned by a legacy hook.
+}
+
+// Changed report that the hook has updated the cli definition. The context is parsed again and hooks executed on
+// a new pass, even if nothing has been added. Ex: a new flag default value.
+func (h *HookContext) Changed() {
+	if h == nil {
+		return
+	}
+	h.changed = true
+}
+
+// IsChanged return true if the hook has reported a change.
+func (h *HookContext) IsChanged() bool {
+	if h == nil {
+		return false
+	}
+	return h.changed
+}
+
+// runOnce return true if the legacy hook has not been executed yet by the current context resolution.
+// Out of context resolution, it is always true.
+func (h *HookContext) runOnce() bool {
+	c := h.Cli
+	if h.Pass == 0 {
+		return true
+	}
+	if c.legacy_hooks[h.name] {
+		return false
+	}
+	if c.legacy_hooks == nil {
+		c.legacy_hooks = make(map[string]bool)
+	}
+	c.legacy_hooks[h.name] = true
+	return true
+}
+
+// Name return the hook name. 'before', 'after', <object> or <object>/<list>
+func (h *HookContext) Name() string {
+	if h == nil {
+		return ""
+	}
+	return h.name
+}
+
+// OnBeforeParse defines a hook executed before object list and object hooks. It replaces ParseBeforeHook.
+func (c *ForjCli) OnBeforeParse(hook HookFunc) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.bef_hook = hook
+	c.bef_ctx_hook = nil
+	return c
+}
+
+// OnAfterParse defines a hook executed after object list and object hooks. It replaces ParseAfterHook.
+func (c *ForjCli) OnAfterParse(hook HookFunc) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.aft_hook = hook
+	c.aft_ctx_hook = nil
+	return c
+}
+
+// SetHookLogger set the logger given to hooks. By default, messages are sent to gotrace.
+func (c *ForjCli) SetHookLogger(logger HookLogger) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.hook_logger = logger
+	return c
+}
+
+// OnParse defines the object parse hook. It replaces ParseHook.
+func (o *ForjObject) OnParse(hook ObjectHookFunc) *ForjObject {
+	if o == nil {
+		return nil
+	}
+	o.hook = hook
+	o.context_hook = nil
+	return o
+}
+
+// OnParse defines the object list parse hook. It replaces ParseHook.
+func (l *ForjObjectList) OnParse(hook ListHookFunc) *ForjObjectList {
+	if l == nil {
+		return nil
+	}
+	l.hook = hook
+	l.context_hook = nil
+	return l
+}
+
+// beforeHook return the before parse hook. A hook set by ParseBeforeHook is adapted.
+func (c *ForjCli) beforeHook() HookFunc {
+	if c.bef_hook != nil {
+		return c.bef_hook
+	}
+	return cliHookAdapter(c.bef_ctx_hook)
+}
+
+// afterHook return the after parse hook. A hook set by ParseAfterHook is adapted.
+func (c *ForjCli) afterHook() HookFunc {
+	if c.aft_hook != nil {
+		return c.aft_hook
+	}
+	return cliHookAdapter(c.aft_ctx_hook)
+}
+
+// hookFunc return the object parse hook. A hook set by ParseHook is adapted, and executed once per parse.
+func (o *ForjObject) hookFunc() ObjectHookFunc {
+	if o.hook != nil {
+		return o.hook
+	}
+	if o.context_hook == nil {
+		return nil
+	}
+	context_hook := o.context_hook
+	return func(o *ForjObject, h *HookContext) error {
+		if !h.runOnce() {
+			return nil
+		}
+		var err error
+		err, h.executed = context_hook(o, h.Cli, h.Data)
+		return err
+	}
+}
+
+// hookFunc return the object list parse hook. A hook set by ParseHook is adapted, and executed once per parse.
+func (l *ForjObjectList) hookFunc() ListHookFunc {
+	if l.hook != nil {
+		return l.hook
+	}
+	if l.context_hook == nil {
+		return nil
+	}
+	context_hook := l.context_hook
+	return func(l *ForjObjectList, h *HookContext) error {
+		if !h.runOnce() {
+			return nil
+		}
+		var err error
+		err, h.executed = context_hook(l, h.Cli, h.Data)
+		return err
+	}
+}
+
+// cliHookAdapter adapt a ParseBeforeHook/ParseAfterHook hook. nil if context_hook is nil.
+//
+// Adapted hooks are executed once per parse.
+func cliHookAdapter(context_hook func(*ForjCli, interface{}) (error, bool)) HookFunc {
+	if context_hook == nil {
+		return nil
+	}
+	return func(h *HookContext) error {
+		if !h.runOnce() {
+			return nil
+		}
+		var err error
+		err, h.executed = context_hook(h.Cli, h.Data)
+		return err
+	}
+}
+
+// newHookContext create the context given to a hook.
+func (c *ForjCli) newHookContext(name string, data interface{}) *HookContext {
+	ctx := c.parse_ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logger := c.hook_logger
+	if logger == nil {
+		logger = traceHookLogger(name)
+	}
+	return &HookContext{
+		Context: ctx,
+		Cli:     c,
+		Pass:    c.hook_pass,
+		Action:  c.cli_context.action,
+		Object:  c.cli_context.object,
+		List:    c.cli_context.list,
+		Data:    data,
+		Log:     logger,
+		name:    name,
+	}
+}
+
+// runHook execute a hook with a new hook context. It fails if the parse context is cancelled.
+//
+// The returned status is true if the hook has reported a change or if a legacy hook has returned true.
+func (c *ForjCli) runHook(name string, data interface{}, hook HookFunc) (error, bool) {
+	h := c.newHookContext(name, data)
+	if err := h.Err(); err != nil {
+		return &ForjHookError{Hook: name, Pass: h.Pass, Cancelled: true, Err: err}, false
+	}
+	if err := hook(h); err != nil {
+		if _, ok := err.(*ForjHookError); ok {
+			return err, false
+		}
+		return &ForjHookError{Hook: name, Pass: h.Pass, Err: err}, false
+	}
+	if h.changed {
+		c.hook_changed = true
+	}
+	return nil, h.changed || h.executed
+}
+
+// traceHookLogger is the default hook logger. Messages are sent to gotrace.
+type traceHookLogger string
+
+func (l traceHookLogger) Trace(format string, a ...interface{}) {
+	gotrace.Trace("hook %s: %s", string(l), fmt.Sprintf(format, a...))
+}
+
+func (l traceHookLogger) Warning(format string, a ...interface{}) {
+	gotrace.Warning("hook %s: %s", string(l), fmt.Sprintf(format, a...))
+}
+
+func (l traceHookLogger) Error(format string, a ...interface{}) {
+	gotrace.Error("hook %s: %s", string(l), fmt.Sprintf(format, a...))
+}
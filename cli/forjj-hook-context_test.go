@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_Parse_HookContext(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to give a typed hook context to OnParse hooks.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	var passes []int
+	var names []string
+	var action_name string
+	var data interface{}
+	c.OnBeforeParse(func(h *HookContext) error {
+		names = append(names, h.Name())
+		return nil
+	})
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		OnParse(func(o *ForjObject, h *HookContext) error {
+			names = append(names, h.Name())
+			passes = append(passes, h.Pass)
+			if h.Action != nil {
+				action_name = h.Action.name
+			}
+			data = h.Data
+			if _, found := h.Cli.actions[create].params["driver-type"]; found {
+				return nil
+			}
+			h.Cli.OnActions(create).AddFlag(String, "driver-type", "driver type help", nil)
+			h.Changed()
+			return nil
+		})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "driver-type", "github"}, "my data")
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, _ := c.GetActionStringValue(create, "driver-type"); v != "github" {
+		t.Errorf("Expected 'driver-type' flag added by the hook to be loaded. Got '%s'", v)
+	}
+	if len(passes) != 2 || passes[0] != 1 || passes[1] != 2 {
+		t.Errorf("Expected the hook to be executed on passes 1 and 2. Got %v", passes)
+	}
+	if v := strings.Join(names, " "); v != "before app before app" {
+		t.Errorf("Expected hooks names to be 'before app before app'. Got '%s'", v)
+	}
+	if action_name != create {
+		t.Errorf("Expected hook context action to be '%s'. Got '%s'", create, action_name)
+	}
+	if data != "my data" {
+		t.Errorf("Expected hook context data to be 'my data'. Got '%v'", data)
+	}
+}
+
+func TestForjCli_Parse_HookContextLegacy(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to adapt ParseHook hooks.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	var changed bool
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		CreateList("to_create", ",", "name", "apps help").
+		OnParse(func(l *ForjObjectList, h *HookContext) error {
+			changed = h.IsChanged()
+			return nil
+		})
+	c.GetObject("app").
+		ParseHook(func(_ *ForjObject, c *ForjCli, data interface{}) (error, bool) {
+			if _, found := c.actions[create].params["driver-type"]; found {
+				return nil, false
+			}
+			c.OnActions(create).AddFlag(String, "driver-type", "driver type help", nil)
+			return nil, true
+		})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "driver-type", "github"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, _ := c.GetActionStringValue(create, "driver-type"); v != "github" {
+		t.Errorf("Expected 'driver-type' flag added by the legacy hook to be loaded. Got '%s'", v)
+	}
+	if changed {
+		t.Error("Expected a new hook context to not be changed.")
+	}
+	if v := len(c.GetContextPasses()); v != 2 {
+		t.Errorf("Expected 2 context passes. Got %d", v)
+	}
+}
+
+func TestForjCli_Parse_HookContextLegacyOnce(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to execute a legacy hook once, even if it always returns true.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	count := 0
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(func(_ *ForjObject, c *ForjCli, _ interface{}) (error, bool) {
+			count++
+			if c.GetObject("driver") != nil {
+				return fmt.Errorf("Object 'driver' already exists."), false
+			}
+			c.NewObject("driver", "driver help", "").
+				AddKey(String, "name", "name help", "", nil)
+			return nil, true
+		})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected the legacy hook to be executed once. Got %d", count)
+	}
+	if v := len(c.GetContextPasses()); v != 2 {
+		t.Errorf("Expected 2 context passes. Got %d", v)
+	}
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{"cmd:" + create}, nil)
+
+	// --- Start testing ---
+	if err == nil || count != 2 {
+		t.Errorf("Expected the legacy hook to be executed again by a new parse. Got %d (%s)", count, err)
+	}
+}
+
+func TestForjCli_ParseWithContext_Cancelled(t *testing.T) {
+	t.Log("Expect ForjCli_ParseWithContext() to fail when the context is cancelled.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	executed := false
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		OnParse(func(*ForjObject, *HookContext) error {
+			executed = true
+			return nil
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// --- Run the test ---
+	_, err := c.ParseWithContext(ctx, []string{"cmd:" + create}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected ParseWithContext() to fail. Got no error.")
+	} else if !strings.Contains(err.Error(), "'app' not started") {
+		t.Errorf("Expected the hook not started error. Got '%s'", err)
+	}
+	if executed {
+		t.Error("Expected the hook to not be executed.")
+	}
+}
+
+func TestForjCli_Parse_HookContextChanged(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to run another pass if a hook reports a change without adding definitions.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.AddAppFlag(String, "driver-type", "driver type help", nil)
+
+	var values []string
+	c.OnBeforeParse(func(h *HookContext) error {
+		v, _ := h.Cli.GetAppStringValue("driver-type")
+		values = append(values, v)
+		if h.Pass == 1 {
+			h.Cli.GetAppFlag("driver-type").Default("github")
+			h.Changed()
+		}
+		return nil
+	})
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	passes := c.GetContextPasses()
+	if len(passes) != 2 || len(passes[0].Added) != 0 {
+		t.Errorf("Expected 2 context passes with nothing added. Got %s", passes)
+	}
+	if v := strings.Join(values, ","); v != ",github" {
+		t.Errorf("Expected the hook to see the updated default on pass 2. Got '%s'", v)
+	}
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Objects and object lists parse hooks are executed in a stable order:
+//
+// - A hook runs after the hooks of objects given by HookAfter.
+// - An object hook runs after its object lists hooks.
+// - Otherwise, hooks with the highest priority run first. Hooks with the same priority run by name order.
+//
+// Ex: c.GetObject("repo").ParseHook(repoHook).HookAfter("infra")
+
+// forjHook is an object or object list parse hook to order.
+type forjHook struct {
+	name     string          // <object> or <object>/<list>
+	object   *ForjObject     // Object owning the hook.
+	list     *ForjObjectList // Set for an object list hook.
+	priority int
+	after    []string // Objects names to run after.
+}
+
+// run execute the hook.
+func (h *forjHook) run(c *ForjCli, data interface{}) (error, bool) {
+	if h.list != nil {
+		hook := h.list.hookFunc()
+		return c.runHook(h.name, data, func(hc *HookContext) error { return hook(h.list, hc) })
+	}
+	hook := h.object.hookFunc()
+	return c.runHook(h.name, data, func(hc *HookContext) error { return hook(h.object, hc) })
+}
+
+// HookPriority set the object parse hook priority. Hooks with the highest priority run first. Default is 0.
+func (o *ForjObject) HookPriority(priority int) *ForjObject {
+	if o == nil {
+		return nil
+	}
+	o.hook_priority = priority
+	return o
+}
+
+// HookAfter declare objects which hooks (object and object lists hooks) must run before this object hooks.
+// It applies to the object hook and to its object lists hooks.
+func (o *ForjObject) HookAfter(objects ...string) *ForjObject {
+	if o == nil {
+		return nil
+	}
+	o.hook_after = append(o.hook_after, objects...)
+	return o
+}
+
+// HookPriority set the object list parse hook priority. Hooks with the highest priority run first. Default is 0.
+func (l *ForjObjectList) HookPriority(priority int) *ForjObjectList {
+	if l == nil {
+		return nil
+	}
+	l.hook_priority = priority
+	return l
+}
+
+// HookAfter declare objects which hooks must run before this object list hook.
+func (l *ForjObjectList) HookAfter(objects ...string) *ForjObjectList {
+	if l == nil {
+		return nil
+	}
+	l.hook_after = append(l.hook_after, objects...)
+	return l
+}
+
+// GetHooksOrder return the objects and object lists parse hooks names in execution order.
+//
+// Object hooks are named <object> and object list hooks <object>/<list>.
+func (c *ForjCli) GetHooksOrder() ([]string, error) {
+	if c == nil {
+		return nil, fmt.Errorf("Unable to order hooks. Cli is nil.")
+	}
+	hooks, err := c.sortedHooks()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(hooks))
+	for _, hook := range hooks {
+		names = append(names, hook.name)
+	}
+	return names, nil
+}
+
+// sortedHooks return the objects and object lists parse hooks in execution order.
+//
+// It fails if a hook depends on an unknown object or if dependencies are cyclic.
+func (c *ForjCli) sortedHooks() ([]*forjHook, error) {
+	hooks := make(map[string]*forjHook)
+	for _, o := range c.objects {
+		for _, l := range o.list {
+			if l.hookFunc() == nil {
+				continue
+			}
+			hook := &forjHook{
+				name:     o.name + "/" + l.name,
+				object:   o,
+				list:     l,
+				priority: l.hook_priority,
+				after:    append(append([]string{}, o.hook_after...), l.hook_after...),
+			}
+			hooks[hook.name] = hook
+		}
+		if o.hookFunc() != nil {
+			hooks[o.name] = &forjHook{name: o.name, object: o, priority: o.hook_priority, after: o.hook_after}
+		}
+	}
+
+	// Build dependencies: hook name => hooks names which must run before.
+	depends := make(map[string]map[string]bool)
+	for name, hook := range hooks {
+		depends[name] = make(map[string]bool)
+		for _, object_name := range hook.after {
+			if _, found := c.objects[object_name]; !found {
+				return nil, fmt.Errorf("Unable to order '%s' hook. It must run after the unknown object '%s'.",
+					name, object_name)
+			}
+			if object_name == hook.object.name {
+				continue
+			}
+			for other_name, other := range hooks {
+				if other.object.name == object_name {
+					depends[name][other_name] = true
+				}
+			}
+		}
+		if hook.list == nil {
+			for other_name, other := range hooks {
+				if other.list != nil && other.object == hook.object {
+					depends[name][other_name] = true
+				}
+			}
+		}
+	}
+
+	sorted := make([]*forjHook, 0, len(hooks))
+	done := make(map[string]bool)
+	for len(sorted) < len(hooks) {
+		ready := make([]*forjHook, 0)
+		for name, hook := range hooks {
+			if done[name] {
+				continue
+			}
+			is_ready := true
+			for dep := range depends[name] {
+				if !done[dep] {
+					is_ready = false
+					break
+				}
+			}
+			if is_ready {
+				ready = append(ready, hook)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("Unable to order hooks. Dependency cycle detected: %s.",
+				hooksCycle(hooks, depends, done))
+		}
+		sort.Sort(forjHooks(ready))
+		// Only the first one is selected, as it can release a hook with a higher priority.
+		done[ready[0].name] = true
+		sorted = append(sorted, ready[0])
+	}
+	return sorted, nil
+}
+
+// hooksCycle return a dependency cycle found in hooks not done. Ex: a -> b -> a
+func hooksCycle(hooks map[string]*forjHook, depends map[string]map[string]bool, done map[string]bool) string {
+	names := make([]string, 0)
+	for name := range hooks {
+		if !done[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Every hook not done has at least one dependency not done. Following them leads to a cycle.
+	path := []string{names[0]}
+	index := map[string]int{names[0]: 0}
+	for {
+		current := path[len(path)-1]
+		deps := make([]string, 0)
+		for dep := range depends[current] {
+			if !done[dep] {
+				deps = append(deps, dep)
+			}
+		}
+		sort.Strings(deps)
+		next := deps[0]
+		if i, found := index[next]; found {
+			cycle := append(path[i:], next)
+			// Displayed in execution order: a -> b means a must run before b.
+			for l, r := 0, len(cycle)-1; l < r; l, r = l+1, r-1 {
+				cycle[l], cycle[r] = cycle[r], cycle[l]
+			}
+			return strings.Join(cycle, " -> ")
+		}
+		index[next] = len(path)
+		path = append(path, next)
+	}
+}
+
+// forjHooks sort hooks by priority (highest first) then by name.
+type forjHooks []*forjHook
+
+func (s forjHooks) Len() int      { return len(s) }
+func (s forjHooks) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s forjHooks) Less(i, j int) bool {
+	if s[i].priority != s[j].priority {
+		return s[i].priority > s[j].priority
+	}
+	return s[i].name < s[j].name
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_GetHooksOrder(t *testing.T) {
+	t.Log("Expect ForjCli_GetHooksOrder() to sort hooks by dependencies, priority and name.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+
+	var executed []string
+	objectHook := func(o *ForjObject, _ *ForjCli, _ interface{}) (error, bool) {
+		executed = append(executed, o.Name())
+		return nil, false
+	}
+	listHook := func(l *ForjObjectList, _ *ForjCli, _ interface{}) (error, bool) {
+		executed = append(executed, l.obj.Name()+"/"+l.name)
+		return nil, false
+	}
+
+	c.NewObject("app", "app help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(objectHook).
+		HookAfter("infra")
+	c.NewObject("infra", "infra help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(objectHook)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(objectHook).
+		CreateList("to_create", ",", "name", "repos help").
+		ParseHook(listHook)
+	c.NewObject("user", "user help", "").
+		AddKey(String, "name", "name help", "", nil).
+		ParseHook(objectHook).
+		HookPriority(10)
+	c.NewObject("group", "group help", "").
+		AddKey(String, "name", "name help", "", nil)
+
+	const expected = "user infra app repo/to_create repo"
+
+	for i := 0; i < 10; i++ {
+		// --- Run the test ---
+		order, err := c.GetHooksOrder()
+
+		// --- Start testing ---
+		if err != nil {
+			t.Errorf("Expected GetHooksOrder() to work. Got '%s'", err)
+			return
+		}
+		if v := strings.Join(order, " "); v != expected {
+			t.Errorf("Expected hooks order to be '%s'. Got '%s'", expected, v)
+			return
+		}
+	}
+
+	// --- Run the test ---
+	err, _ := c.contextHook(nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected contextHook() to work. Got '%s'", err)
+	}
+	if v := strings.Join(executed, " "); v != expected {
+		t.Errorf("Expected hooks to be executed as '%s'. Got '%s'", expected, v)
+	}
+}
+
+func TestForjCli_GetHooksOrder_Errors(t *testing.T) {
+	t.Log("Expect ForjCli_GetHooksOrder() to fail on cycles and unknown objects.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	hook := func(*ForjObject, *ForjCli, interface{}) (error, bool) {
+		return nil, false
+	}
+	c.NewObject("a", "a help", "").ParseHook(hook).HookAfter("c")
+	c.NewObject("b", "b help", "").ParseHook(hook).HookAfter("a")
+	c.NewObject("c", "c help", "").ParseHook(hook).HookAfter("b")
+
+	// --- Run the test ---
+	_, err := c.GetHooksOrder()
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected GetHooksOrder() to fail on a cycle. Got no error.")
+	} else if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected the cycle to be reported. Got '%s'", err)
+	}
+	if err, _ := c.contextHook(nil); err == nil {
+		t.Error("Expected contextHook() to fail on a cycle. Got no error.")
+	}
+
+	// --- Setting test context ---
+	c = NewForjCli(kingpinMock.New("Application"))
+	c.NewObject("a", "a help", "").ParseHook(hook).HookAfter("unknown")
+
+	// --- Run the test ---
+	_, err = c.GetHooksOrder()
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected GetHooksOrder() to fail on an unknown object. Got no error.")
+	}
+}
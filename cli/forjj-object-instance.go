@@ -38,7 +38,8 @@ func (i *ForjObjectInstance) addField(o *ForjObject, pIntType, name, help, re st
 		return nil
 	}
 	if i.hasField(name) {
-		o.err = fmt.Errorf("Additionnal field '%s' already exist.", name)
+		o.err = fmt.Errorf("Additionnal field '%s' already exist, declared by %s.", name,
+			i.additional_fields[name].ownersString())
 		return nil
 	}
 	f := NewField(o, pIntType, name, help, re, opts)
@@ -30,12 +30,19 @@ type ForjObject struct {
 	single       bool                                                   // Max 1 record if single = true
 	err          error                                                  // Last error found.
 	context_hook func(*ForjObject, *ForjCli, interface{}) (error, bool) // Parse hook related to this object. Can use cli to create more.
+	hook         ObjectHookFunc                                         // Parse hook set by OnParse.
+	// Parse hook ordering. See HookPriority and HookAfter.
+	hook_priority int
+	hook_after    []string
 
 	sel_instance string // Selected instance name.
 }
 
 // createObjectDataFromParams creates object data from the given list of params
+//
+// All invalid values are reported.
 func (o *ForjObject) createObjectDataFromParams(params map[string]ForjParam) error {
+	var errs ForjErrors
 	instances := o.getInstancesFromParams(params)
 	for _, instance := range instances {
 		instance_name := to_string(instance)
@@ -67,12 +74,21 @@ func (o *ForjObject) createObjectDataFromParams(params map[string]ForjParam) err
 			}
 			v, _ := p.GetContextValue(o.cli.cli_context.context)
 			field_name := p.forjParamRelated().getFieldName()
+			origin := paramOrigin(o.cli.cli_context.context, p, v)
 			if f, found := o.fields[field_name]; found {
-				obj_data.set(f.value_type, field_name, v)
+				if err := f.checkValue(instance_name, v, origin.String()); err != nil {
+					errs.Add(err)
+					continue
+				}
+				obj_data.setFrom(f.value_type, field_name, v, origin)
 			} else {
 				if i, found := o.instances[instance_name]; found {
 					if fi, found := i.additional_fields[field_name]; found {
-						obj_data.set(fi.value_type, field_name, v)
+						if err := fi.checkValue(instance_name, v, origin.String()); err != nil {
+							errs.Add(err)
+							continue
+						}
+						obj_data.setFrom(fi.value_type, field_name, v, origin)
 					} else {
 						gotrace.Warning("Internal issue! Unable to find additional field '%s'.", field_name)
 					}
@@ -83,7 +99,7 @@ func (o *ForjObject) createObjectDataFromParams(params map[string]ForjParam) err
 			p.forjParamUpdater().set_ref(obj_data)
 		}
 	}
-	return nil
+	return errs.ErrorOrNil()
 }
 
 func (o *ForjObject) IsSingle() bool {
@@ -167,7 +183,7 @@ func (o *ForjObject) String() string {
 	ret += fmt.Sprintf("  cli: %p\n", o.cli)
 	ret += fmt.Sprintf("  name: '%s'\n", o.name)
 	ret += fmt.Sprintf("  desc: '%s'\n", o.desc)
-	if o.context_hook == nil {
+	if o.hookFunc() == nil {
 		ret += fmt.Sprintf("  Hook: %t\n", false)
 	} else {
 		ret += fmt.Sprintf("  Hook: %t\n", true)
@@ -200,6 +216,7 @@ func (o *ForjObject) String() string {
 //   where repo is a cmd and params store all object flags/args
 type ForjObjectAction struct {
 	name    string               // object action name (formatted as <action>_<object>)
+	help    string               // Command help
 	cmd     clier.CmdClauser     // Object
 	action  *ForjAction          // Parent Action name and help
 	plugins []string             // Plugins implementing this object action.
@@ -269,12 +286,17 @@ func (o *ForjObject) OnActions(list ...string) *ForjObject {
 	return o
 }
 
-// setErr - Set an error flag to the cli and none exists.
+// setErr - Add an error to the object error flag. (See setError)
 func (o *ForjObject) setErr(format string, a ...interface{}) {
-	if o.err != nil {
-		return
-	}
-	o.err = fmt.Errorf(format, a...)
+	o.setError(fmt.Errorf(format, a...))
+}
+
+// setError - Add a typed error to the object error flag. Several errors are aggregated in a ForjErrors.
+func (o *ForjObject) setError(err error) {
+	errs := ForjErrors{}
+	errs.Add(o.err)
+	errs.Add(err)
+	o.err = errs.ErrorOrNil()
 }
 
 // cleanErr - Cleanup cli error flag.
@@ -289,6 +311,7 @@ func (o *ForjObject) ParseHook(context_hook func(*ForjObject, *ForjCli, interfac
 		return nil
 	}
 	o.context_hook = context_hook
+	o.hook = nil
 	return o
 }
 
@@ -356,7 +379,7 @@ func (o *ForjObject) OnInstance(instance_name string) *ForjObject {
 	}
 
 	if _, found := o.instances[instance_name]; !found {
-		o.setErr("Instance '%s' is not found.", instance_name)
+		o.setError(&ForjUnknownInstanceError{Object: o.name, Instance: instance_name})
 		return nil
 	}
 	o.sel_instance = instance_name
@@ -370,7 +393,7 @@ func (o *ForjObject) addParam(newParam func() ForjParam, name string, options *F
 	var field *ForjField
 
 	if v, found := o.fields[name]; !found {
-		o.err = fmt.Errorf("Unable to find '%s' field in Object '%s'.", name, o.name)
+		o.err = &ForjUnknownFieldError{Object: o.name, Field: name}
 		return nil
 	} else {
 		field = v
@@ -532,12 +555,13 @@ func (o *ForjObject) AddField(pIntType, name, help, re string, opts *ForjOpts) *
 	}
 
 	if o.HasField(name) {
-		gotrace.Warning("Field %s already added in %s. Ignored.", name, o.name)
+		gotrace.Warning("Field %s already added in %s by %s. Ignored.", name, o.name, o.fields[name].ownersString())
 		return o
 	}
 
 	if found, as_object_field := o.IsObjectField(name); found && !as_object_field {
-		o.setErr("Unable to add object field. Field %s already exist in %s at object instance level.", name, o.name)
+		o.setErr("Unable to add object field. Field %s already exist in %s at object instance level, declared by %s.",
+			name, o.name, o.instanceFieldOwners(name))
 		return nil
 	}
 
@@ -545,16 +569,29 @@ func (o *ForjObject) AddField(pIntType, name, help, re string, opts *ForjOpts) *
 		gotrace.Warning("Field '%s' was configured with NO regexp. Defaulting to '.*'", name)
 		re = ".*"
 	}
+	o.checkCaptures(name, re)
 	o.fields[name] = NewField(o, pIntType, name, help, re, opts)
 
 	if o.IsSingle() {
 		// Add single object field as attribute and default values if found
 		value := opts.GetDefault(pIntType)
-		o.cli.SetValue(o.name, o.name, pIntType, name, value)
+		_, origin := opts.defaultValue()
+		o.cli.SetValueFrom(o.name, o.name, pIntType, name, value, origin)
 	}
 	return o
 }
 
+// checkCaptures reports an error if the field regexp uses list captures not defined by AddFieldListCapture.
+//
+// The field is declared anyway, but its values are not validated against its regexp.
+func (o *ForjObject) checkCaptures(name, re string) {
+	if captures := o.cli.unresolvedCaptures(re); len(captures) > 0 {
+		gotrace.Error("Field '%s' regexp '%s' of object '%s' uses undefined list capture(s) '#%s'. Define them with "+
+			"AddFieldListCapture() before the field. Values of this field are not validated.",
+			name, re, o.name, strings.Join(captures, "', '#"))
+	}
+}
+
 // AddInstanceField add a field to the object.
 func (o *ForjObject) AddInstanceField(instance, pIntType, name, help, re string, opts *ForjOpts) *ForjObject {
 	if o == nil {
@@ -572,7 +609,8 @@ func (o *ForjObject) AddInstanceField(instance, pIntType, name, help, re string,
 	}
 
 	if found, as_object_field := o.IsObjectField(name); found && as_object_field {
-		o.setErr("Unable to add instance field. Field %s already exist in %s at object level.", name, o.name)
+		o.setErr("Unable to add instance field. Field %s already exist in %s at object level, declared by %s.",
+			name, o.name, o.fields[name].ownersString())
 		return nil
 	}
 
@@ -590,6 +628,7 @@ func (o *ForjObject) AddInstanceField(instance, pIntType, name, help, re string,
 		gotrace.Trace("Field '%s' already added in %s as instance field. Ignored.", name, o.name)
 		return o
 	}
+	o.checkCaptures(name, re)
 	oi.addField(o, pIntType, name, help, re, opts)
 
 	// As we add an instance field, automatically, an instance record with key set to the instance will be created.
@@ -630,6 +669,16 @@ func (o *ForjObject) IsObjectField(name string) (found bool, has_object_field bo
 	return
 }
 
+// instanceFieldOwners return owners of the first instance field found with this name.
+func (o *ForjObject) instanceFieldOwners(name string) string {
+	for _, instance := range o.instances {
+		if field, found := instance.additional_fields[name]; found {
+			return field.ownersString()
+		}
+	}
+	return ""
+}
+
 // buildListRegExp convert a human readable to Regexp
 // [] are considered as optional and replaced by ()?
 // Any word string are identified as a field are replaced by the template object field associated RegExp.
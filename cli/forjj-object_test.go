@@ -790,7 +790,7 @@ func TestForjObject_SetParamOptions(t *testing.T) {
 		key_value        = "key-value"
 		flag             = "flag"
 		flag_help        = "flag help"
-		flag_value       = "flag value"
+		flag_value       = "flag-value"
 		myapp            = "app"
 		apps             = "apps"
 		app_help         = "app help"
@@ -802,7 +802,7 @@ func TestForjObject_SetParamOptions(t *testing.T) {
 		driver_type_help = "driver_type help"
 		flag2            = "flag2"
 		flag2_help       = "flag2 help"
-		flag2_value      = "flag2 value"
+		flag2_value      = "flag2-value"
 		myinstance       = "myapp"
 	)
 	// --- Setting test context ---
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// MaxPromptAttempts is the maximum number of times a value is asked when the answer is invalid.
+const MaxPromptAttempts = 3
+
+// ForjPromptQuestion describe a missing value to ask to the user.
+type ForjPromptQuestion struct {
+	Object   string   // Object name
+	Instance string   // Object instance name. Empty when the key is asked.
+	Field    string   // Field name
+	Help     string   // Field help
+	Default  string   // Value used if the answer is empty.
+	Enum     []string // Accepted values, if any.
+	Regexp   string   // Field regexp the answer must respect, if any.
+	Key      bool     // true if the object key is asked.
+	Error    error    // Previous answer error, when the question is asked again.
+}
+
+// ForjPrompter ask missing values to the user. See ForjCli.Interactive
+type ForjPrompter interface {
+	Prompt(q *ForjPromptQuestion) (string, error)
+}
+
+// ForjLinePrompter is a ForjPrompter which writes questions to an io.Writer and read answers, one per line, from
+// an io.Reader.
+type ForjLinePrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewLinePrompter create a ForjLinePrompter. Used by InteractiveTerminal with os.Stdin and os.Stdout.
+func NewLinePrompter(in io.Reader, out io.Writer) *ForjLinePrompter {
+	return &ForjLinePrompter{in: bufio.NewReader(in), out: out}
+}
+
+// Prompt print the question and read the answer. An empty answer returns the question default value.
+func (p *ForjLinePrompter) Prompt(q *ForjPromptQuestion) (string, error) {
+	if p == nil || q == nil {
+		return "", fmt.Errorf("Unable to prompt. Prompter or question is nil.")
+	}
+	if q.Error != nil {
+		fmt.Fprintf(p.out, "%s\n", q.Error)
+	}
+	if q.Help != "" {
+		fmt.Fprintf(p.out, "%s\n", q.Help)
+	}
+	question := q.Field
+	if q.Instance != "" {
+		question += " of " + q.Object + " '" + q.Instance + "'"
+	} else {
+		question += " of " + q.Object
+	}
+	if len(q.Enum) > 0 {
+		question += " (" + strings.Join(q.Enum, "|") + ")"
+	}
+	if q.Default != "" {
+		question += " [" + q.Default + "]"
+	}
+	fmt.Fprintf(p.out, "%s: ", question)
+
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", fmt.Errorf("Unable to read %s value. %s", q.Field, err)
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return q.Default, nil
+	}
+	return answer, nil
+}
+
+// Interactive enable the interactive mode. Missing object keys and missing prompted fields (See ForjOpts.Prompt)
+// are asked with p and the answers are loaded as if given on the command line.
+//
+// Keys and fields asked must not be Required by the parser. Use ForjOpts.Prompt instead of ForjOpts.Required.
+// A nil prompter disables the interactive mode.
+func (c *ForjCli) Interactive(p ForjPrompter) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.prompter = p
+	return c
+}
+
+// InteractiveTerminal enable the interactive mode on the terminal, only if the standard input is a terminal.
+func (c *ForjCli) InteractiveTerminal() *ForjCli {
+	if c == nil {
+		return nil
+	}
+	if stat, err := os.Stdin.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		gotrace.Trace("Standard input is not a terminal. Interactive mode not enabled.")
+		return c
+	}
+	return c.Interactive(NewLinePrompter(os.Stdin, os.Stdout))
+}
+
+// IsInteractive return true if missing values are asked to the user.
+func (c *ForjCli) IsInteractive() bool {
+	if c == nil {
+		return false
+	}
+	return c.prompter != nil
+}
+
+// promptValue ask a missing field value. The answer is validated and asked again, up to MaxPromptAttempts times.
+//
+// Answers are kept for the parse task, so that context resolution passes do not ask them again.
+func (c *ForjCli) promptValue(o *ForjObject, instance string, field *ForjField, options *ForjOpts) (string, error) {
+	id := o.name + "/" + instance + "/" + field.name
+	if v, found := c.prompted[id]; found {
+		return v, nil
+	}
+	if options == nil {
+		options = field.options
+	}
+	q := &ForjPromptQuestion{
+		Object:   o.name,
+		Instance: instance,
+		Field:    field.name,
+		Help:     field.help,
+		Default:  options.declaredDefault(),
+		Enum:     options.GetEnum(),
+		Regexp:   field.regexp,
+		Key:      field.key,
+	}
+	if q.Enum == nil {
+		q.Enum = field.options.GetEnum()
+	}
+	for i := 0; i < MaxPromptAttempts; i++ {
+		answer, err := c.prompter.Prompt(q)
+		if err != nil {
+			return "", &ForjMissingValueError{Object: o.name, Instance: instance, Field: field.name, Err: err}
+		}
+		if answer == "" {
+			q.Error = &ForjMissingValueError{Object: o.name, Instance: instance, Field: field.name}
+			continue
+		}
+		if q.Error = field.checkValue(instance, answer, SourcePrompt); q.Error != nil {
+			continue
+		}
+		gotrace.Trace("'%s' prompted value is '%s'.", id, answer)
+		if c.prompted == nil {
+			c.prompted = make(map[string]string)
+		}
+		c.prompted[id] = answer
+		return answer, nil
+	}
+	return "", q.Error
+}
+
+// missingValue return the value of a missing prompted field, asked in interactive mode.
+//
+// If the interactive mode is disabled, a ForjMissingValueError is returned.
+func (c *ForjCli) missingValue(o *ForjObject, instance string, field *ForjField, options *ForjOpts) (string, error) {
+	if !c.IsInteractive() {
+		return "", &ForjMissingValueError{Object: o.name, Instance: instance, Field: field.name}
+	}
+	return c.promptValue(o, instance, field, options)
+}
+
+// paramOptions return flag or arg options. nil for other params.
+func paramOptions(p ForjParam) *ForjOpts {
+	switch param := p.(type) {
+	case *ForjFlag:
+		return param.options
+	case *ForjArg:
+		return param.options
+	}
+	return nil
+}
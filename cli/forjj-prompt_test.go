@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_Parse_Prompt(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to ask missing key and fields in interactive mode.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "title", "title help", ".*", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Prompt()).
+		AddFlag("title", Opts().Prompt())
+	out := new(bytes.Buffer)
+	c.Interactive(NewLinePrompter(strings.NewReader("Bad1\nmyrepo\nmy title\n"), out))
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:repo"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, found, _, _ := c.GetStringValue("repo", "myrepo", "name"); !found || v != "myrepo" {
+		t.Errorf("Expected prompted key to be 'myrepo'. Got '%s'", v)
+	}
+	if v, found, _, _ := c.GetStringValue("repo", "myrepo", "title"); !found || v != "my title" {
+		t.Errorf("Expected prompted title to be 'my title'. Got '%s'", v)
+	}
+	if o, _, _ := c.GetValueOrigin("repo", "myrepo", "title"); o.Source != SourcePrompt {
+		t.Errorf("Expected title origin to be '%s'. Got '%s'", SourcePrompt, o.Source)
+	}
+	if v := out.String(); !strings.Contains(v, "Invalid value 'Bad1'") {
+		t.Errorf("Expected the invalid key to be reported and asked again. Got '%s'", v)
+	}
+	if v := strings.Count(out.String(), "name of repo: "); v != 2 {
+		t.Errorf("Expected the key to be asked 2 times. Got %d", v)
+	}
+}
+
+// fakePrompter answers questions with a list of answers and records questions.
+type fakePrompter struct {
+	answers   []string
+	questions []ForjPromptQuestion
+}
+
+func (p *fakePrompter) Prompt(q *ForjPromptQuestion) (string, error) {
+	p.questions = append(p.questions, *q)
+	if len(p.questions) > len(p.answers) {
+		return "", nil
+	}
+	return p.answers[len(p.questions)-1], nil
+}
+
+func TestForjCli_promptValue(t *testing.T) {
+	t.Log("Expect ForjCli_promptValue() to give up after MaxPromptAttempts invalid answers.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "title", "title help", ".*", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Prompt()).
+		AddFlag("title", Opts().Prompt())
+	p := &fakePrompter{answers: []string{"A", "B"}}
+	c.Interactive(p)
+	o := c.GetObject("repo")
+
+	// --- Run the test ---
+	_, err := c.promptValue(o, "", o.fields["name"], nil)
+
+	// --- Start testing ---
+	if v := len(p.questions); v != MaxPromptAttempts {
+		t.Errorf("Expected the value to be asked %d times. Got %d", MaxPromptAttempts, v)
+	}
+	if v := GetErrorCode(err); v != ErrMissingValue {
+		t.Errorf("Expected the last error to be a missing value. Got '%s'", err)
+	}
+	if len(p.questions) > 1 && (GetErrorCode(p.questions[1].Error) != ErrValidationFailed || !p.questions[1].Key) {
+		t.Errorf("Expected the key to be asked again with the validation error. Got %#v", p.questions[1])
+	}
+}
+
+func TestForjCli_Parse_MissingValue(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to fail on missing prompted values without interactive mode.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "title", "title help", ".*", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Prompt()).
+		AddFlag("title", Opts().Prompt())
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:repo", "name", "myrepo"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail. Got no error.")
+		return
+	}
+	if e, ok := err.(*ForjMissingValueError); !ok || e.Field != "title" || e.Instance != "myrepo" {
+		t.Errorf("Expected a missing 'title' value error. Got '%s'", err)
+	}
+}
+
+func TestForjCli_Complete_NoPrompt(t *testing.T) {
+	t.Log("Expect ForjCli_Complete() to never ask missing values.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "title", "title help", ".*", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Prompt()).
+		AddFlag("title", Opts().Prompt())
+	p := &fakePrompter{answers: []string{"myrepo", "my title"}}
+	c.Interactive(p)
+
+	// --- Run the test ---
+	c.Complete([]string{"cmd:" + create, "cmd:repo", ""}, nil)
+
+	// --- Start testing ---
+	if v := len(p.questions); v != 0 {
+		t.Errorf("Expected no question to be asked. Got %d", v)
+	}
+	if !c.IsInteractive() {
+		t.Error("Expected the interactive mode to be restored.")
+	}
+}
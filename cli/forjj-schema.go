@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"encoding/json"
+)
+
+// ForjSchemaVersion is the version of the cli schema document format.
+//
+// It must be increased on any incompatible change of ForjSchemaDoc.
+const ForjSchemaVersion = 1
+
+// ForjSchemaDoc describes the cli definition: application flags, actions, objects, fields and lists.
+//
+// The document is built from declarations only. Flags added at parse time (instance flags) are not described.
+// Maps are exported with sorted keys, so 2 documents can be compared to detect cli changes between releases.
+type ForjSchemaDoc struct {
+	Version     int                         `json:"version"`
+	Application string                      `json:"application"`
+	Flags       map[string]ForjSchemaParam  `json:"flags,omitempty"`
+	Actions     map[string]ForjSchemaAction `json:"actions,omitempty"`
+	Objects     map[string]ForjSchemaObject `json:"objects,omitempty"`
+}
+
+// ForjSchemaAction describes an action or an object action command.
+type ForjSchemaAction struct {
+	Help     string                     `json:"help,omitempty"`
+	Internal bool                       `json:"internal,omitempty"` // True if the action cannot be enhanced by plugins.
+	Params   map[string]ForjSchemaParam `json:"params,omitempty"`
+}
+
+// ForjSchemaParam describes a flag or an argument.
+type ForjSchemaParam struct {
+	Kind    string             `json:"kind"` // flag, arg, flag-list or arg-list
+	Type    string             `json:"type"`
+	Help    string             `json:"help,omitempty"`
+	Object  string             `json:"object,omitempty"` // Object name if the param is an object field.
+	Field   string             `json:"field,omitempty"`  // Object field name set by the param.
+	List    string             `json:"list,omitempty"`   // Object list name if the param is a list.
+	Options *ForjSchemaOptions `json:"options,omitempty"`
+}
+
+// ForjSchemaOptions describes param or field options.
+type ForjSchemaOptions struct {
+	Required bool     `json:"required,omitempty"`
+	Default  string   `json:"default,omitempty"`
+	Envar    string   `json:"envar,omitempty"`
+	Short    string   `json:"short,omitempty"`
+	Hidden   bool     `json:"hidden,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+}
+
+// ForjSchemaObject describes an object.
+type ForjSchemaObject struct {
+	Help      string                                `json:"help,omitempty"`
+	Role      string                                `json:"role,omitempty"`
+	Single    bool                                  `json:"single,omitempty"`
+	Key       string                                `json:"key,omitempty"` // Key field name.
+	Fields    map[string]ForjSchemaField            `json:"fields,omitempty"`
+	Instances map[string]map[string]ForjSchemaField `json:"instances,omitempty"` // Instances additional fields.
+	Actions   map[string]ForjSchemaAction           `json:"actions,omitempty"`
+	Lists     map[string]ForjSchemaList             `json:"lists,omitempty"`
+}
+
+// ForjSchemaField describes an object field.
+type ForjSchemaField struct {
+	Type    string             `json:"type"`
+	Help    string             `json:"help,omitempty"`
+	Key     bool               `json:"key,omitempty"`
+	Regexp  string             `json:"regexp,omitempty"` // Value validation regexp.
+	Options *ForjSchemaOptions `json:"options,omitempty"`
+}
+
+// ForjSchemaList describes an object list.
+type ForjSchemaList struct {
+	Help    string                      `json:"help,omitempty"`
+	Sep     string                      `json:"separator"`
+	Sample  string                      `json:"sample"`
+	Regexp  string                      `json:"regexp"` // Capturing regexp of a list element.
+	Fields  map[string]uint             `json:"fields"` // Regexp capture index per field name.
+	Key     string                      `json:"key,omitempty"`
+	Actions map[string]ForjSchemaAction `json:"actions,omitempty"` // List commands. (<action> <object>s)
+}
+
+// ExportSchema return the cli definition as a document.
+func (c *ForjCli) ExportSchema() *ForjSchemaDoc {
+	doc := &ForjSchemaDoc{Version: ForjSchemaVersion}
+	if c == nil {
+		return doc
+	}
+	doc.Application = c.App.Name()
+
+	doc.Flags = make(map[string]ForjSchemaParam)
+	for name, f := range c.flags {
+		doc.Flags[name] = schemaParam(f)
+	}
+
+	doc.Actions = make(map[string]ForjSchemaAction)
+	for name, action := range c.actions {
+		doc.Actions[name] = ForjSchemaAction{
+			Help:     action.desc,
+			Internal: action.internal_only,
+			Params:   schemaParams(action.params),
+		}
+	}
+
+	doc.Objects = make(map[string]ForjSchemaObject)
+	for name, o := range c.objects {
+		doc.Objects[name] = c.schemaObject(o)
+	}
+	return doc
+}
+
+// ExportSchemaJSON return the cli definition as an indented JSON document.
+func (c *ForjCli) ExportSchemaJSON() ([]byte, error) {
+	return json.MarshalIndent(c.ExportSchema(), "", "  ")
+}
+
+// ExportJSONSchema return a JSON Schema (draft-07) validating configuration files. See AddConfigFile.
+//
+// Application flags are described under 'flags'. Objects instances fields are described under
+// 'objects/<object>/<instance>' and single objects fields under 'objects/<object>'.
+func (c *ForjCli) ExportJSONSchema() ([]byte, error) {
+	schema := map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type":    "object",
+	}
+	if c == nil {
+		return json.MarshalIndent(schema, "", "  ")
+	}
+	schema["title"] = c.App.Name()
+
+	flags := make(map[string]interface{})
+	for name, f := range c.flags {
+		flags[name] = jsonSchemaValue(f.value_type, f.help, f.options, "")
+	}
+
+	objects := make(map[string]interface{})
+	for name, o := range c.objects {
+		fields := jsonSchemaFields(o.fields, nil)
+		if o.single {
+			objects[name] = fields
+			continue
+		}
+		instances := map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": fields,
+		}
+		properties := make(map[string]interface{})
+		for instance_name, instance := range o.instances {
+			if len(instance.additional_fields) > 0 {
+				properties[instance_name] = jsonSchemaFields(o.fields, instance.additional_fields)
+			}
+		}
+		if len(properties) > 0 {
+			instances["properties"] = properties
+		}
+		objects[name] = instances
+	}
+
+	schema["properties"] = map[string]interface{}{
+		"flags":   jsonSchemaObject(flags),
+		"objects": jsonSchemaObject(objects),
+	}
+	schema["additionalProperties"] = false
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// jsonSchemaObject return the JSON Schema of an object with a fixed list of properties.
+func jsonSchemaObject(properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// jsonSchemaFields return the JSON Schema of an object instance fields.
+func jsonSchemaFields(fields, additional_fields map[string]*ForjField) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, fields := range []map[string]*ForjField{fields, additional_fields} {
+		for name, f := range fields {
+			pattern := ""
+			if f.regexp != "" && f.regexp != ".*" {
+				if re, err := f.compiledRegexp(); err == nil {
+					pattern = re.String()
+				}
+			}
+			properties[name] = jsonSchemaValue(f.value_type, f.help, f.options, pattern)
+		}
+	}
+	return jsonSchemaObject(properties)
+}
+
+// jsonSchemaValue return the JSON Schema of a typed value.
+func jsonSchemaValue(value_type, help string, options *ForjOpts, pattern string) map[string]interface{} {
+	ret := make(map[string]interface{})
+	if help != "" {
+		ret["description"] = help
+	}
+	switch value_type {
+	case Bool:
+		ret["type"] = "boolean"
+	case Int:
+		ret["type"] = "integer"
+	case Float:
+		ret["type"] = "number"
+	case StringSlice:
+		item := map[string]interface{}{"type": "string"}
+		if pattern != "" {
+			item["pattern"] = pattern
+		}
+		ret["type"] = "array"
+		ret["items"] = item
+		return ret
+	case StringMap:
+		value := map[string]interface{}{"type": "string"}
+		if pattern != "" {
+			value["pattern"] = pattern
+		}
+		ret["type"] = "object"
+		ret["additionalProperties"] = value
+		return ret
+	default:
+		ret["type"] = "string"
+	}
+	if pattern != "" {
+		ret["pattern"] = pattern
+	}
+	if enum := options.GetEnum(); len(enum) > 0 {
+		ret["enum"] = enum
+	}
+	return ret
+}
+
+// schemaObject return the object description.
+func (c *ForjCli) schemaObject(o *ForjObject) ForjSchemaObject {
+	obj := ForjSchemaObject{
+		Help:    o.desc,
+		Role:    o.role,
+		Single:  o.single,
+		Key:     o.getKeyName(),
+		Fields:  c.schemaFields(o.fields),
+		Actions: make(map[string]ForjSchemaAction),
+		Lists:   make(map[string]ForjSchemaList),
+	}
+	for name, instance := range o.instances {
+		if len(instance.additional_fields) == 0 {
+			continue
+		}
+		if obj.Instances == nil {
+			obj.Instances = make(map[string]map[string]ForjSchemaField)
+		}
+		obj.Instances[name] = c.schemaFields(instance.additional_fields)
+	}
+	for name, action := range o.actions {
+		obj.Actions[name] = ForjSchemaAction{Help: action.help, Params: schemaParams(action.params)}
+	}
+	for name, l := range o.list {
+		list := ForjSchemaList{
+			Help:    l.help,
+			Sep:     l.sep,
+			Sample:  l.sample,
+			Fields:  make(map[string]uint),
+			Key:     l.key_name,
+			Actions: make(map[string]ForjSchemaAction),
+		}
+		if l.ext_regexp != nil {
+			list.Regexp = l.ext_regexp.String()
+		}
+		for index, field_name := range l.fields_name {
+			list.Fields[field_name] = index
+		}
+		for action_name, action := range l.actions {
+			list.Actions[action_name] = ForjSchemaAction{Help: action.help, Params: schemaParams(action.params)}
+		}
+		obj.Lists[name] = list
+	}
+	return obj
+}
+
+// schemaFields return fields descriptions.
+func (c *ForjCli) schemaFields(fields map[string]*ForjField) map[string]ForjSchemaField {
+	ret := make(map[string]ForjSchemaField)
+	for name, f := range fields {
+		ret[name] = ForjSchemaField{
+			Type:    f.value_type,
+			Help:    f.help,
+			Key:     f.key,
+			Regexp:  c.buildCapture(f.regexp),
+			Options: schemaOptions(f.options),
+		}
+	}
+	return ret
+}
+
+// schemaParams return params descriptions. Instance flags (--<instance>-<field>) are not described.
+func schemaParams(params map[string]ForjParam) map[string]ForjSchemaParam {
+	ret := make(map[string]ForjSchemaParam)
+	for name, p := range params {
+		if l, instance, _ := p.fromList(); l != nil && instance != "" {
+			continue
+		}
+		ret[name] = schemaParam(p)
+	}
+	return ret
+}
+
+// schemaParam return a param description.
+func schemaParam(p ForjParam) (ret ForjSchemaParam) {
+	switch param := p.(type) {
+	case *ForjFlag:
+		ret = ForjSchemaParam{Kind: "flag", Type: param.value_type, Help: param.help, Field: param.field_name,
+			Options: schemaOptions(param.options)}
+		if param.obj != nil {
+			ret.Object = param.obj.name
+		}
+		if param.list != nil {
+			ret.Object = param.list.obj.name
+			ret.List = param.list.name
+		}
+	case *ForjArg:
+		ret = ForjSchemaParam{Kind: "arg", Type: param.value_type, Help: param.help, Field: param.field_name,
+			Options: schemaOptions(param.options)}
+		if param.obj != nil {
+			ret.Object = param.obj.name
+		}
+		if param.list != nil {
+			ret.Object = param.list.obj.name
+			ret.List = param.list.name
+		}
+	case *ForjFlagList:
+		ret = ForjSchemaParam{Kind: "flag-list", Type: List, Help: param.help}
+		if param.obj != nil {
+			ret.Object = param.obj.obj.name
+			ret.List = param.obj.name
+		}
+	case *ForjArgList:
+		ret = ForjSchemaParam{Kind: "arg-list", Type: List, Help: param.obj.help, Object: param.obj.obj.name,
+			List: param.obj.name, Options: &ForjSchemaOptions{Required: true}}
+	}
+	return
+}
+
+// schemaOptions return options description. nil if no options are set.
+func schemaOptions(o *ForjOpts) *ForjSchemaOptions {
+	if o == nil || len(o.opts) == 0 {
+		return nil
+	}
+	ret := &ForjSchemaOptions{
+		Required: o.IsRequired(),
+		Default:  o.declaredDefault(),
+		Hidden:   o.isHidden(),
+		Enum:     o.GetEnum(),
+	}
+	_, ret.Envar = o.HasEnvar()
+	switch v := o.opts["short"].(type) {
+	case byte:
+		ret.Short = string(rune(v))
+	case rune:
+		ret.Short = string(v)
+	}
+	if !ret.Required && ret.Default == "" && ret.Envar == "" && ret.Short == "" && !ret.Hidden && ret.Enum == nil {
+		return nil
+	}
+	return ret
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"encoding/json"
+	"forjj-modules/cli/kingpinMock"
+	"testing"
+)
+
+func TestForjCli_ExportSchema(t *testing.T) {
+	t.Log("Expect ForjCli_ExportSchema() to describe actions, objects, fields, lists and app flags.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(Bool, "debug", "debug help", Opts().Envar("DEBUG").Short('d'))
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject(workspace, "workspace help", "").Single().
+		AddField(String, "path", "path help", "", Opts().Default("/tmp"))
+	c.NewObject("repo", "repo help", "infra").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddField(Int, "port", "port help", "", nil).
+		AddInstanceField("infra", StringSlice, "labels", "labels help", "[a-z]+", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name[:kind]", "repos help").
+		AddActions(create)
+
+	// --- Run the test ---
+	doc := c.ExportSchema()
+
+	// --- Start testing ---
+	if doc.Version != ForjSchemaVersion {
+		t.Errorf("Expected schema version %d. Got %d", ForjSchemaVersion, doc.Version)
+	}
+	if f := doc.Flags["debug"]; f.Kind != "flag" || f.Type != Bool || f.Options == nil ||
+		f.Options.Envar != "DEBUG" || f.Options.Short != "d" {
+		t.Errorf("Expected 'debug' app flag with its options. Got %#v", f)
+	}
+	if a := doc.Actions[create]; a.Help != create_help || !a.Internal {
+		t.Errorf("Expected '%s' internal action. Got %#v", create, a)
+	}
+
+	repo, found := doc.Objects["repo"]
+	if !found {
+		t.Error("Expected 'repo' object to be described.")
+		return
+	}
+	if repo.Key != "name" || repo.Role != "infra" || repo.Single {
+		t.Errorf("Expected 'repo' key and role. Got %#v", repo)
+	}
+	if f := repo.Fields["name"]; !f.Key || f.Regexp != "("+w_f+")" {
+		t.Errorf("Expected 'name' key field with expanded regexp. Got %#v", f)
+	}
+	if f := repo.Fields["kind"]; f.Options == nil || len(f.Options.Enum) != 2 {
+		t.Errorf("Expected 'kind' field enum. Got %#v", f)
+	}
+	if f, found := repo.Instances["infra"]["labels"]; !found || f.Type != StringSlice {
+		t.Errorf("Expected 'infra' instance 'labels' field. Got %#v", repo.Instances)
+	}
+	if p := repo.Actions[create].Params["name"]; p.Kind != "flag" || p.Object != "repo" || p.Field != "name" ||
+		p.Options == nil || !p.Options.Required {
+		t.Errorf("Expected 'name' required flag on 'create repo'. Got %#v", p)
+	}
+
+	l, found := repo.Lists["to_create"]
+	if !found {
+		t.Error("Expected 'to_create' list to be described.")
+		return
+	}
+	if l.Sep != "," || l.Sample != "name[:kind]" || l.Regexp == "" || l.Fields["name"] != 1 || l.Fields["kind"] == 0 {
+		t.Errorf("Expected list separator, sample, regexp and fields indexes. Got %#v", l)
+	}
+	if p := l.Actions[create].Params["repos"]; p.Kind != "arg-list" || p.List != "to_create" {
+		t.Errorf("Expected 'repos' arg list on 'create repos'. Got %#v", p)
+	}
+	if doc.Objects[workspace].Single != true {
+		t.Error("Expected 'workspace' object to be single.")
+	}
+}
+
+func TestForjCli_ExportJSONSchema(t *testing.T) {
+	t.Log("Expect ForjCli_ExportJSONSchema() to return a JSON Schema of config files.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(Bool, "debug", "debug help", Opts().Envar("DEBUG").Short('d'))
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject(workspace, "workspace help", "").Single().
+		AddField(String, "path", "path help", "", Opts().Default("/tmp"))
+	c.NewObject("repo", "repo help", "infra").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddField(Int, "port", "port help", "", nil).
+		AddInstanceField("infra", StringSlice, "labels", "labels help", "[a-z]+", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name[:kind]", "repos help").
+		AddActions(create)
+
+	// --- Run the test ---
+	data, err := c.ExportJSONSchema()
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected ExportJSONSchema() to work. Got '%s'", err)
+		return
+	}
+	var schema struct {
+		Properties struct {
+			Flags struct {
+				Properties map[string]map[string]interface{}
+			}
+			Objects struct {
+				Properties map[string]struct {
+					Properties           map[string]map[string]interface{}
+					AdditionalProperties json.RawMessage
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Errorf("Expected a JSON document. Got '%s'", err)
+		return
+	}
+	if v := schema.Properties.Flags.Properties["debug"]["type"]; v != "boolean" {
+		t.Errorf("Expected 'debug' flag type to be boolean. Got '%v'", v)
+	}
+	if v := schema.Properties.Objects.Properties[workspace].Properties["path"]["type"]; v != "string" {
+		t.Errorf("Expected 'workspace/path' type to be string. Got '%v'", v)
+	}
+	var instances struct {
+		Properties map[string]map[string]interface{}
+	}
+	if err := json.Unmarshal(schema.Properties.Objects.Properties["repo"].AdditionalProperties, &instances); err != nil {
+		t.Errorf("Expected 'repo' instances schema. Got '%s'", err)
+		return
+	}
+	repo := instances.Properties
+	if v := repo["name"]["pattern"]; v != "^(?:("+w_f+"))$" {
+		t.Errorf("Expected 'repo/name' pattern to be the full key regexp. Got '%v'", v)
+	}
+	if v := repo["port"]["type"]; v != "integer" {
+		t.Errorf("Expected 'repo/port' type to be integer. Got '%v'", v)
+	}
+	if v, ok := repo["kind"]["enum"].([]interface{}); !ok || len(v) != 2 {
+		t.Errorf("Expected 'repo/kind' enum. Got '%v'", repo["kind"]["enum"])
+	}
+	if _, found := schema.Properties.Objects.Properties["repo"].Properties["infra"]; !found {
+		t.Error("Expected 'infra' instance to be described with its additional fields.")
+	}
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// ForjSecretExec is a SecretProvider running a helper command to get secrets.
+//
+// The helper is started with its arguments followed by the secret reference ('<object>/<instance>/<field>').
+// It writes the secret on its standard output. An empty output means the secret is unknown. The helper fails with
+// a non zero exit status.
+type ForjSecretExec struct {
+	command string   // Helper command
+	args    []string // Helper arguments, given before the secret reference.
+}
+
+// NewSecretExec create a SecretProvider running command with args.
+//
+// Ex: NewSecretExec("pass", "show") runs 'pass show github/infra/token'
+func NewSecretExec(command string, args ...string) *ForjSecretExec {
+	return &ForjSecretExec{command: command, args: args}
+}
+
+// GetSecret run the helper and return its output. Ending new lines are removed.
+func (s *ForjSecretExec) GetSecret(ref ForjSecretRef) (string, bool, error) {
+	if s == nil || s.command == "" {
+		return "", false, nil
+	}
+	cmd := exec.Command(s.command, append(append([]string{}, s.args...), ref.String())...)
+	// The helper error output is not kept, as it could contain the secret.
+	stdout := new(bytes.Buffer)
+	cmd.Stdout = stdout
+	gotrace.Trace("Running secret helper '%s' for '%s'.", s.command, ref)
+	if err := cmd.Run(); err != nil {
+		return "", false, fmt.Errorf("Secret helper '%s' fails. %s", s.command, err)
+	}
+	v := strings.TrimRight(stdout.String(), "\r\n")
+	if v == "" {
+		return "", false, nil
+	}
+	addSecret(v)
+	return v, true, nil
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Encrypted secret file key derivation parameters. (PBKDF2-HMAC-SHA256)
+const (
+	secretFileSaltSize   = 16
+	secretFileIterations = 100000
+	secretFileKeySize    = 32 // AES-256
+)
+
+// ForjSecretFile is a SecretProvider reading secrets from a local file encrypted with a passphrase given by an
+// environment variable. See WriteSecretFile.
+//
+// The file is a base64 document of an AES-256-GCM encrypted JSON map of secrets. Secrets are identified by
+// '<object>/<instance>/<field>' or by '<object>/<field>' for all instances of an object.
+type ForjSecretFile struct {
+	file   string // Encrypted file path.
+	envar  string // Environment variable giving the passphrase.
+	once   sync.Once
+	values map[string]string // Decrypted secrets.
+	err    error             // Load error.
+}
+
+// NewSecretFile create a SecretProvider reading the encrypted file with the passphrase given by envar.
+//
+// The file is read once, when the first secret is requested. A missing file provides no secret.
+func NewSecretFile(file, envar string) *ForjSecretFile {
+	return &ForjSecretFile{file: file, envar: envar}
+}
+
+// GetSecret return the secret identified by ref, or by '<object>/<field>'.
+func (s *ForjSecretFile) GetSecret(ref ForjSecretRef) (string, bool, error) {
+	if s == nil {
+		return "", false, nil
+	}
+	s.once.Do(s.load)
+	if s.err != nil {
+		return "", false, s.err
+	}
+	if v, found := s.values[ref.String()]; found {
+		return v, true, nil
+	}
+	v, found := s.values[ref.Object+"/"+ref.Field]
+	return v, found, nil
+}
+
+// load decrypt the secret file.
+func (s *ForjSecretFile) load() {
+	data, err := ioutil.ReadFile(expandHome(s.file))
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		s.err = fmt.Errorf("Unable to read secret file '%s'. %s", s.file, err)
+		return
+	}
+	passphrase := os.Getenv(s.envar)
+	if passphrase == "" {
+		s.err = fmt.Errorf("Unable to decrypt secret file '%s'. Passphrase environment variable '%s' is not set.",
+			s.file, s.envar)
+		return
+	}
+	if s.values, err = decryptSecrets(data, passphrase); err != nil {
+		s.err = fmt.Errorf("Unable to decrypt secret file '%s'. %s", s.file, err)
+		return
+	}
+	for _, v := range s.values {
+		addSecret(v)
+	}
+}
+
+// WriteSecretFile encrypt secrets in file with passphrase. The file is readable by its owner only.
+//
+// Secrets are identified by '<object>/<instance>/<field>' or by '<object>/<field>'. See ForjSecretFile.
+func WriteSecretFile(file, passphrase string, secrets map[string]string) error {
+	if passphrase == "" {
+		return fmt.Errorf("Unable to encrypt secret file '%s'. The passphrase is empty.", file)
+	}
+	data, err := encryptSecrets(secrets, passphrase)
+	if err != nil {
+		return fmt.Errorf("Unable to encrypt secret file '%s'. %s", file, err)
+	}
+	if err := ioutil.WriteFile(expandHome(file), data, 0600); err != nil {
+		return fmt.Errorf("Unable to write secret file '%s'. %s", file, err)
+	}
+	return nil
+}
+
+// encryptSecrets return the encrypted document. (base64 of salt + nonce + encrypted JSON)
+func encryptSecrets(secrets map[string]string, passphrase string) ([]byte, error) {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, secretFileSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := secretFileCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	data := append(append(salt, nonce...), gcm.Seal(nil, nonce, plain, nil)...)
+	return []byte(base64.StdEncoding.EncodeToString(data) + "\n"), nil
+}
+
+// decryptSecrets return the secrets of an encrypted document.
+func decryptSecrets(doc []byte, passphrase string) (map[string]string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(doc)))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid secret file format. %s", err)
+	}
+	if len(data) < secretFileSaltSize {
+		return nil, fmt.Errorf("Invalid secret file format. File too short.")
+	}
+	gcm, err := secretFileCipher(passphrase, data[:secretFileSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[secretFileSaltSize:]
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("Invalid secret file format. File too short.")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("Wrong passphrase or corrupted file.")
+	}
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("Invalid secret file content. %s", err)
+	}
+	return secrets, nil
+}
+
+// secretFileCipher return the AES-GCM cipher of a passphrase.
+func secretFileCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, secretFileIterations, secretFileKeySize, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
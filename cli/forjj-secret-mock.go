@@ -0,0 +1,36 @@
+package cli
+
+import "sync"
+
+// ForjSecretMock is a SecretProvider test double. It gives secrets from a map and records requested references.
+//
+// Secrets are identified by '<object>/<instance>/<field>' or by '<object>/<field>'. (See ForjSecretFile)
+type ForjSecretMock struct {
+	sync.Mutex
+	Secrets   map[string]string // Known secrets.
+	Err       error             // Error returned by GetSecret, if set.
+	Requested []ForjSecretRef   // References requested, in order.
+}
+
+// NewSecretMock create a SecretProvider test double knowing secrets.
+func NewSecretMock(secrets map[string]string) *ForjSecretMock {
+	if secrets == nil {
+		secrets = make(map[string]string)
+	}
+	return &ForjSecretMock{Secrets: secrets}
+}
+
+// GetSecret record the request and return the known secret, or Err.
+func (m *ForjSecretMock) GetSecret(ref ForjSecretRef) (string, bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.Requested = append(m.Requested, ref)
+	if m.Err != nil {
+		return "", false, m.Err
+	}
+	if v, found := m.Secrets[ref.String()]; found {
+		return v, true, nil
+	}
+	v, found := m.Secrets[ref.Object+"/"+ref.Field]
+	return v, found, nil
+}
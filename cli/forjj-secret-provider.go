@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// ForjSecretRef identifies the secret field value requested to a SecretProvider.
+type ForjSecretRef struct {
+	Object   string // Object name
+	Instance string // Object instance name. (The object name for a single object)
+	Field    string // Field name
+}
+
+// String return the reference as '<object>/<instance>/<field>'
+func (r ForjSecretRef) String() string {
+	return r.Object + "/" + r.Instance + "/" + r.Field
+}
+
+// SecretProvider give secret fields values not given by the command line or the environment.
+//
+// found is false if the provider does not know the secret. err is set if the provider fails.
+type SecretProvider interface {
+	GetSecret(ref ForjSecretRef) (value string, found bool, err error)
+}
+
+// ForjSecretProviders is a SecretProvider which consults a list of providers, in order, until one finds the secret.
+type ForjSecretProviders []SecretProvider
+
+// GetSecret return the secret of the first provider which knows it.
+func (p ForjSecretProviders) GetSecret(ref ForjSecretRef) (string, bool, error) {
+	for _, provider := range p {
+		if provider == nil {
+			continue
+		}
+		if v, found, err := provider.GetSecret(ref); err != nil || found {
+			return v, found, err
+		}
+	}
+	return "", false, nil
+}
+
+// SecretProvider define the provider consulted when a secret field (See ForjOpts.Secret) has no value from the
+// command line or the environment. Use ForjSecretProviders to consult several providers.
+//
+// A nil provider disables it.
+func (c *ForjCli) SecretProvider(p SecretProvider) *ForjCli {
+	if c == nil {
+		return nil
+	}
+	c.secret_provider = p
+	return c
+}
+
+// providerSecret return a secret field value from the secret provider. The value is empty if there is no provider or
+// if the provider does not know the secret.
+func (c *ForjCli) providerSecret(object, instance string, field *ForjField) (string, ForjValueOrigin, error) {
+	origin := ForjValueOrigin{Source: SourceProvider, Name: field.name, secret: true}
+	if c.secret_provider == nil || !field.isSecret() {
+		return "", origin, nil
+	}
+	ref := ForjSecretRef{Object: object, Instance: instance, Field: field.name}
+	v, found, err := c.secret_provider.GetSecret(ref)
+	if err != nil {
+		return "", origin, fmt.Errorf("Unable to get secret '%s' from the secret provider. %s", ref, err)
+	}
+	if !found || v == "" {
+		gotrace.Trace("Secret '%s' not found by the secret provider.", ref)
+		return "", origin, nil
+	}
+	addSecret(v)
+	origin.Raw = v
+	gotrace.Trace("Secret '%s' given by the secret provider.", ref)
+	return v, origin, nil
+}
+
+// loadProviderSecrets set secret fields of all objects instances which have no value, from the secret provider.
+//
+// It is called once parsed, so that the provider is consulted once per secret. A prompted secret of the object
+// instance given on the command line is asked if the provider does not know it.
+func (c *ForjCli) loadProviderSecrets() error {
+	if c.secret_provider == nil {
+		return nil
+	}
+	var errs ForjErrors
+	for object_name, r := range c.values {
+		o, found := c.objects[object_name]
+		if !found || r == nil {
+			continue
+		}
+		for instance, data := range r.records {
+			for _, field_name := range o.getInstanceFieldsName(instance) {
+				field := o.getInstanceField(instance, field_name)
+				if !field.isSecret() || rawString(data.attrs[field_name]) != "" {
+					continue
+				}
+				v, origin, err := c.providerSecret(object_name, instance, field)
+				if err != nil {
+					errs.Add(err)
+					continue
+				}
+				if v == "" {
+					if v, origin, err = c.missingProviderSecret(o, instance, field); err != nil {
+						errs.Add(err)
+						continue
+					}
+				}
+				if v == "" {
+					continue
+				}
+				if _, err := data.setFrom(field.value_type, field_name, v, origin); err != nil {
+					errs.Add(err)
+				}
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// missingProviderSecret ask a prompted secret not given by the secret provider. Only the object instance identified
+// by the command line is asked.
+func (c *ForjCli) missingProviderSecret(o *ForjObject, instance string, field *ForjField) (string, ForjValueOrigin, error) {
+	origin := ForjValueOrigin{Source: SourcePrompt, Name: field.name, secret: true}
+	if c.cli_context.action == nil || c.cli_context.object != o || c.cli_context.instance != instance {
+		return "", origin, nil
+	}
+	action, found := o.actions[c.cli_context.action.name]
+	if !found {
+		return "", origin, nil
+	}
+	param, found := action.params[field.name]
+	if !found || !paramOptions(param).IsPrompted() {
+		return "", origin, nil
+	}
+	answer, err := c.missingValue(o, instance, field, paramOptions(param))
+	if err != nil {
+		return "", origin, err
+	}
+	origin.Raw = answer
+	return answer, origin, nil
+}
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"forjj-modules/cli/kingpinMock"
+)
+
+func TestForjCli_Parse_SecretProvider(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to get missing secrets from the secret provider.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	provider := NewSecretMock(map[string]string{"github/token": "fromprovider"})
+	c.SecretProvider(provider)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, _, _, _ := c.GetStringValue("github", "gh", "token"); v != "fromprovider" {
+		t.Errorf("Expected the token to be given by the provider. Got '%s'", v)
+	}
+	if o, _, _ := c.GetValueOrigin("github", "gh", "token"); o.Source != SourceProvider || o.Raw != SecretMask {
+		t.Errorf("Expected the token origin to be a masked '%s' value. Got '%#v'", SourceProvider, o)
+	}
+	if len(provider.Requested) == 0 || provider.Requested[0].String() != "github/gh/token" {
+		t.Errorf("Expected 'github/gh/token' to be requested. Got %v", provider.Requested)
+	}
+
+	// --- Setting test context ---
+	c = NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	provider = NewSecretMock(map[string]string{"github/token": "fromprovider"})
+	c.SecretProvider(provider)
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "fromcli"}, nil)
+
+	// --- Start testing ---
+	if v, _, _, _ := c.GetStringValue("github", "gh", "token"); err != nil || v != "fromcli" {
+		t.Errorf("Expected the token to be given by the cli. Got '%s' (%s)", v, err)
+	}
+	if len(provider.Requested) != 0 {
+		t.Errorf("Expected the provider to not be consulted. Got %v", provider.Requested)
+	}
+}
+
+func TestForjCli_Parse_SecretProviderError(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to report secret provider failures.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	provider := NewSecretMock(nil)
+	provider.Err = fmt.Errorf("provider down.")
+	c.SecretProvider(provider)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail. Got no error.")
+	}
+}
+
+func TestForjCli_Parse_SecretProviderOnce(t *testing.T) {
+	t.Log("Expect the secret provider to be consulted once per parse, and never while completing.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	provider := NewSecretMock(map[string]string{"github/token": "fromprovider"})
+	c.SecretProvider(provider)
+	c.OnBeforeParse(func(h *HookContext) error {
+		if h.Pass == 1 {
+			h.Changed()
+		}
+		return nil
+	})
+
+	// --- Run the test ---
+	c.Complete([]string{"cmd:" + create, "cmd:github", "name", "gh", ""}, nil)
+
+	// --- Start testing ---
+	if len(provider.Requested) != 0 {
+		t.Errorf("Expected the provider to not be consulted while completing. Got %v", provider.Requested)
+	}
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v := len(c.GetContextPasses()); v != 2 {
+		t.Errorf("Expected 2 context passes. Got %d", v)
+	}
+	if len(provider.Requested) != 1 {
+		t.Errorf("Expected the provider to be consulted once. Got %v", provider.Requested)
+	}
+}
+
+func TestForjCli_Parse_SecretProviderPrompt(t *testing.T) {
+	t.Log("Expect a prompted secret to be asked only if the secret provider does not know it.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret().Prompt()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	provider := NewSecretMock(map[string]string{"github/infra/token": "fromprovider"})
+	c.SecretProvider(provider)
+	p := &fakePrompter{answers: []string{"fromprompt"}}
+	c.Interactive(p)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "infra"}, nil)
+
+	// --- Start testing ---
+	if v, _, _, _ := c.GetStringValue("github", "infra", "token"); err != nil || v != "fromprovider" {
+		t.Errorf("Expected the token to be given by the provider. Got '%s' (%s)", v, err)
+	}
+	if len(p.questions) != 0 {
+		t.Errorf("Expected no question. Got %d", len(p.questions))
+	}
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{"cmd:" + create, "cmd:github", "name", "app"}, nil)
+
+	// --- Start testing ---
+	if v, _, _, _ := c.GetStringValue("github", "app", "token"); err != nil || v != "fromprompt" {
+		t.Errorf("Expected the token to be prompted. Got '%s' (%s)", v, err)
+	}
+	if o, _, _ := c.GetValueOrigin("github", "app", "token"); o.Source != SourcePrompt || o.Raw != SecretMask {
+		t.Errorf("Expected the token origin to be a masked '%s' value. Got '%#v'", SourcePrompt, o)
+	}
+	if len(p.questions) != 1 {
+		t.Errorf("Expected the token to be asked once. Got %d", len(p.questions))
+	}
+}
+
+func TestForjSecretProviders_GetSecret(t *testing.T) {
+	t.Log("Expect ForjSecretProviders_GetSecret() to return the first secret found.")
+
+	// --- Setting test context ---
+	first := NewSecretMock(map[string]string{"github/infra/token": "first"})
+	second := NewSecretMock(map[string]string{"github/token": "second"})
+	providers := ForjSecretProviders{first, nil, second}
+
+	tests := []struct {
+		ref      ForjSecretRef
+		expected string
+		found    bool
+	}{
+		{ForjSecretRef{"github", "infra", "token"}, "first", true},
+		{ForjSecretRef{"github", "app", "token"}, "second", true},
+		{ForjSecretRef{"gitlab", "app", "token"}, "", false},
+	}
+
+	for _, test := range tests {
+		// --- Run the test ---
+		v, found, err := providers.GetSecret(test.ref)
+
+		// --- Start testing ---
+		if err != nil || v != test.expected || found != test.found {
+			t.Errorf("Expected '%s' to be '%s' (%t). Got '%s' (%t, %s)", test.ref, test.expected, test.found, v, found, err)
+		}
+	}
+}
+
+func TestForjSecretFile_GetSecret(t *testing.T) {
+	t.Log("Expect ForjSecretFile_GetSecret() to decrypt secrets written by WriteSecretFile().")
+
+	// --- Setting test context ---
+	dir, err := ioutil.TempDir("", "forjj-secret")
+	if err != nil {
+		t.Errorf("Unable to create a temporary directory. %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "secrets")
+	const envar = "FORJJ_CLI_TEST_PASSPHRASE"
+	if err := WriteSecretFile(file, "my passphrase", map[string]string{"github/infra/token": "s3cr3t"}); err != nil {
+		t.Errorf("Expected WriteSecretFile() to work. Got '%s'", err)
+		return
+	}
+	os.Setenv(envar, "my passphrase")
+	defer os.Unsetenv(envar)
+
+	// --- Run the test ---
+	v, found, err := NewSecretFile(file, envar).GetSecret(ForjSecretRef{"github", "infra", "token"})
+
+	// --- Start testing ---
+	if err != nil || !found || v != "s3cr3t" {
+		t.Errorf("Expected the secret to be 's3cr3t'. Got '%s' (%t, %s)", v, found, err)
+	}
+	if data, _ := ioutil.ReadFile(file); string(data) == "" || filepath.Base(string(data)) == "s3cr3t" {
+		t.Error("Expected the secret file to be encrypted.")
+	}
+
+	// --- Run the test ---
+	os.Setenv(envar, "wrong passphrase")
+	_, _, err = NewSecretFile(file, envar).GetSecret(ForjSecretRef{"github", "infra", "token"})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected a wrong passphrase to fail. Got no error.")
+	}
+
+	// --- Run the test ---
+	_, found, err = NewSecretFile(filepath.Join(dir, "missing"), envar).GetSecret(ForjSecretRef{"github", "infra", "token"})
+
+	// --- Start testing ---
+	if err != nil || found {
+		t.Errorf("Expected a missing file to provide no secret. Got %t (%s)", found, err)
+	}
+}
+
+func TestForjSecretExec_GetSecret(t *testing.T) {
+	t.Log("Expect ForjSecretExec_GetSecret() to return the helper output.")
+
+	// --- Setting test context ---
+	helper := NewSecretExec("sh", "-c", "echo value-$1", "helper")
+	failing := NewSecretExec("sh", "-c", "echo oops >&2; exit 1", "helper")
+	ref := ForjSecretRef{"github", "infra", "token"}
+
+	// --- Run the test ---
+	v, found, err := helper.GetSecret(ref)
+
+	// --- Start testing ---
+	if err != nil || !found || v != "value-github/infra/token" {
+		t.Errorf("Expected the secret to be 'value-github/infra/token'. Got '%s' (%t, %s)", v, found, err)
+	}
+
+	// --- Run the test ---
+	_, _, err = failing.GetSecret(ref)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected a failing helper to fail. Got no error.")
+	} else if strings.Contains(err.Error(), "oops") {
+		t.Errorf("Expected the helper error output to not be reported. Got '%s'", err)
+	}
+}
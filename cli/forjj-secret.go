@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// SecretMask replaces secret values in ForjCli.String(), ForjRecords.String(), exports and validation errors.
+const SecretMask = "***"
+
+// SecretSource read a secret value from a reference. See AddSecretSource.
+type SecretSource func(ref string) (string, error)
+
+// SecretStdin is read by the '@-' secret value.
+var SecretStdin io.Reader = os.Stdin
+
+// Secret values sources, resolved values and values registered in gotrace. Shared by all ForjCli, as gotrace secrets.
+var secrets = struct {
+	sync.Mutex
+	sources    map[string]SecretSource // Sources per value prefix.
+	resolved   map[string]string       // Resolved values per reference value. Stdin can be read only once.
+	registered map[string]bool         // Values given to gotrace.AddSecrets.
+}{
+	sources:    map[string]SecretSource{"@": readSecretFile},
+	resolved:   make(map[string]string),
+	registered: make(map[string]bool),
+}
+
+// AddSecretSource define a secret value source. A secret value starting with prefix is replaced by the value
+// returned by source, given the rest of the value.
+//
+// Ex: AddSecretSource("vault:", myVaultReader) reads '--token vault:github/token' with myVaultReader("github/token")
+//
+// The '@' prefix is defined by default: '@<file>' reads the secret from a file, '@-' from SecretStdin.
+func AddSecretSource(prefix string, source SecretSource) {
+	if prefix == "" {
+		return
+	}
+	secrets.Lock()
+	defer secrets.Unlock()
+	if source == nil {
+		delete(secrets.sources, prefix)
+		return
+	}
+	secrets.sources[prefix] = source
+}
+
+// readSecretFile read a secret from a file, or from SecretStdin if file is '-'. Ending new lines are removed.
+func readSecretFile(file string) (string, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = ioutil.ReadAll(SecretStdin)
+	} else {
+		data, err = ioutil.ReadFile(expandHome(file))
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// resolveSecret return the secret value given by value. If value starts with a secret source prefix, the source is
+// read. The longest prefix wins.
+//
+// The resolved value is registered in gotrace, so that it is never displayed.
+func resolveSecret(value string) (string, error) {
+	secrets.Lock()
+	defer secrets.Unlock()
+	if v, found := secrets.resolved[value]; found {
+		return v, nil
+	}
+	prefix := ""
+	for p := range secrets.sources {
+		if strings.HasPrefix(value, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	ret := value
+	if prefix != "" {
+		v, err := secrets.sources[prefix](value[len(prefix):])
+		if err != nil {
+			return "", fmt.Errorf("Unable to read secret from '%s'. %s", value, err)
+		}
+		ret = v
+		secrets.resolved[value] = ret
+	}
+	registerSecret(ret)
+	return ret, nil
+}
+
+// isSecretRef return true if value starts with a secret source prefix.
+func isSecretRef(value string) bool {
+	secrets.Lock()
+	defer secrets.Unlock()
+	for p := range secrets.sources {
+		if strings.HasPrefix(value, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// addSecret register a secret value in gotrace.
+func addSecret(value string) {
+	secrets.Lock()
+	defer secrets.Unlock()
+	registerSecret(value)
+}
+
+// resolveSecretValue resolve a string secret value. Other values are returned unchanged.
+func resolveSecretValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return resolveSecret(v)
+	case *string:
+		if v == nil {
+			return value, nil
+		}
+		s, err := resolveSecret(*v)
+		if err != nil {
+			return nil, err
+		}
+		return &s, nil
+	}
+	return value, nil
+}
+
+// registerSecret register a value in gotrace. Empty and already registered values are ignored. secrets must be
+// locked.
+func registerSecret(value string) {
+	if value == "" || secrets.registered[value] {
+		return
+	}
+	secrets.registered[value] = true
+	gotrace.AddSecrets(value)
+}
+
+// isSecret return true if the field value is a secret.
+func (f *ForjField) isSecret() bool {
+	return f != nil && f.options.IsSecret()
+}
+
+// isSecret return true if the flag value is a secret. (flag or field option)
+func (f *ForjFlag) isSecret() bool {
+	if f == nil {
+		return false
+	}
+	return f.options.IsSecret() || paramField(f.obj, f.list, f.instance_name, f.field_name).isSecret()
+}
+
+// isSecret return true if the arg value is a secret. (arg or field option)
+func (a *ForjArg) isSecret() bool {
+	if a == nil {
+		return false
+	}
+	return a.options.IsSecret() || paramField(a.obj, a.list, a.instance_name, a.field_name).isSecret()
+}
+
+// paramSecret return true if a flag or arg value is a secret.
+func paramSecret(p ForjParam) bool {
+	switch param := p.(type) {
+	case *ForjFlag:
+		return param.isSecret()
+	case *ForjArg:
+		return param.isSecret()
+	}
+	return false
+}
+
+// paramField return the object field attached to a param. nil if the param is not attached to a field.
+func paramField(o *ForjObject, l *ForjObjectList, instance, field_name string) *ForjField {
+	if o == nil && l != nil {
+		o = l.obj
+	}
+	if o == nil || field_name == "" {
+		return nil
+	}
+	return o.getInstanceField(instance, field_name)
+}
+
+// isSecretField return true if an object instance field value is a secret.
+func (c *ForjCli) isSecretField(object, instance, field_name string) bool {
+	if o, found := c.objects[object]; found {
+		return o.getInstanceField(instance, field_name).isSecret()
+	}
+	if f, found := c.flags[field_name]; found && object == internal_app {
+		return f.isSecret()
+	}
+	return false
+}
+
+// resolveSecretParams replace secret flags and args values by the secret they reference. (See AddSecretSource)
+//
+// It is executed after the parse, as parsed values are used by Get*Value functions. Secrets are never read while
+// the cli context is loaded: object instances values loaded from the context are resolved here too.
+func (c *ForjCli) resolveSecretParams() error {
+	var errs ForjErrors
+	errs.Add(c.resolveSecretValues())
+	done := make(map[*string]bool) // A param can be shared by several actions.
+	errs.Add(resolveParamsSecrets(flagsParams(c.flags), done))
+	for _, action := range c.actions {
+		errs.Add(resolveParamsSecrets(action.params, done))
+	}
+	for _, o := range c.objects {
+		for _, action := range o.actions {
+			errs.Add(resolveParamsSecrets(action.params, done))
+		}
+	}
+	for _, l := range c.list {
+		for _, action := range l.actions {
+			errs.Add(resolveParamsSecrets(action.params, done))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// resolveSecretValues replace secret references loaded in object instances by the secret they reference. The secret
+// is checked against the field type and regexp.
+func (c *ForjCli) resolveSecretValues() error {
+	var errs ForjErrors
+	for object_name, r := range c.values {
+		for instance, data := range r.records {
+			for field_name, value := range data.attrs {
+				if !isSecretRef(rawString(value)) || !c.isSecretField(object_name, instance, field_name) {
+					continue
+				}
+				v, err := resolveSecretValue(value)
+				if err != nil {
+					errs.Add(fmt.Errorf("Invalid '%s' secret value. %s", field_name, err))
+					continue
+				}
+				if o, found := c.objects[object_name]; found {
+					origin, _ := data.GetOrigin(field_name)
+					if err := o.getInstanceField(instance, field_name).checkValue(instance, v, origin.String()); err != nil {
+						errs.Add(err)
+						continue
+					}
+				}
+				data.attrs[field_name] = v
+			}
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// resolveParamsSecrets resolve secret values of a params collection. Values already resolved are given by done.
+func resolveParamsSecrets(params map[string]ForjParam, done map[*string]bool) error {
+	var errs ForjErrors
+	for name, p := range params {
+		var value interface{}
+		switch param := p.(type) {
+		case *ForjFlag:
+			if !param.isSecret() {
+				continue
+			}
+			value = param.flagv
+		case *ForjArg:
+			if !param.isSecret() {
+				continue
+			}
+			value = param.argv
+		default:
+			continue
+		}
+		s, ok := value.(*string)
+		if !ok || s == nil || *s == "" || done[s] {
+			continue
+		}
+		done[s] = true
+		v, err := resolveSecret(*s)
+		if err != nil {
+			errs.Add(fmt.Errorf("Invalid '%s' secret value. %s", name, err))
+			continue
+		}
+		*s = v
+	}
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_Parse_SecretFile(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to read secrets from a file and to mask them.")
+
+	// --- Setting test context ---
+	dir, err := ioutil.TempDir("", "forjj-secret")
+	if err != nil {
+		t.Errorf("Unable to create a temporary directory. %s", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "token")
+	ioutil.WriteFile(file, []byte("s3cr3tfile\n"), 0600)
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "@" + file}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, _, _, _ := c.GetStringValue("github", "gh", "token"); v != "s3cr3tfile" {
+		t.Errorf("Expected the token to be read from the file. Got '%s'", v)
+	}
+	if o, _, _ := c.GetValueOrigin("github", "gh", "token"); o.Raw != SecretMask {
+		t.Errorf("Expected the token origin raw value to be masked. Got '%s'", o.Raw)
+	}
+	if v := c.values["github"].String(); strings.Contains(v, "s3cr3tfile") || !strings.Contains(v, SecretMask) {
+		t.Errorf("Expected the token to be masked in records. Got '%s'", v)
+	}
+	if v := c.String(); strings.Contains(v, "s3cr3tfile") {
+		t.Errorf("Expected the token to be masked in the cli description. Got '%s'", v)
+	}
+	if v, _ := c.ExportValuesJSON(); strings.Contains(string(v), "s3cr3tfile") {
+		t.Errorf("Expected the token to be masked in exported values. Got '%s'", v)
+	}
+}
+
+func TestForjCli_Parse_SecretSource(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to read secrets from an added secret source.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	AddSecretSource("test:", func(ref string) (string, error) {
+		return "from" + ref, nil
+	})
+	defer AddSecretSource("test:", nil)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "test:source"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if v, _, _, _ := c.GetStringValue("github", "gh", "token"); v != "fromsource" {
+		t.Errorf("Expected the token to be read from the source. Got '%s'", v)
+	}
+}
+
+func TestForjCli_Parse_SecretValidation(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to mask invalid secret values in errors.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "BAD-Value"}, nil)
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected Parse() to fail. Got no error.")
+		return
+	}
+	if strings.Contains(err.Error(), "BAD-Value") {
+		t.Errorf("Expected the invalid secret to be masked. Got '%s'", err)
+	}
+}
+
+func Test_readSecretFile_Stdin(t *testing.T) {
+	t.Log("Expect readSecretFile() to read '-' from SecretStdin.")
+
+	// --- Setting test context ---
+	stdin := SecretStdin
+	SecretStdin = strings.NewReader("s3cr3tstdin\r\n")
+	defer func() { SecretStdin = stdin }()
+
+	// --- Run the test ---
+	v, err := readSecretFile("-")
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected readSecretFile() to work. Got '%s'", err)
+	}
+	if v != "s3cr3tstdin" {
+		t.Errorf("Expected the secret to be 's3cr3tstdin'. Got '%s'", v)
+	}
+}
+
+func TestForjOpts_Secret(t *testing.T) {
+	t.Log("Expect a secret to never have a default value.")
+
+	// --- Setting test context ---
+	opts := Opts().Secret().Default("default")
+
+	// --- Start testing ---
+	if v := opts.GetDefault(String); v != nil {
+		t.Errorf("Expected no default value. Got '%s'", to_string(v))
+	}
+	if !opts.IsSecret() {
+		t.Error("Expected options to be secret.")
+	}
+}
+
+func TestForjCli_SecretSource_AfterParse(t *testing.T) {
+	t.Log("Expect secret sources to be read after the parse only, and the secret to be checked.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+	var refs []string
+	AddSecretSource("after:", func(ref string) (string, error) {
+		refs = append(refs, ref)
+		return ref, nil
+	})
+	defer AddSecretSource("after:", nil)
+	var hook_value string
+	c.OnAfterParse(func(h *HookContext) error {
+		hook_value, _, _, _ = h.Cli.GetStringValue("github", "gh", "token")
+		return nil
+	})
+
+	// --- Run the test ---
+	c.Complete([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "after:complete", ""}, nil)
+
+	// --- Start testing ---
+	if len(refs) != 0 {
+		t.Errorf("Expected Complete() to not read secrets. Got %s", refs)
+	}
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "after:parse"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if hook_value != "after:parse" {
+		t.Errorf("Expected hooks to get the secret reference. Got '%s'", hook_value)
+	}
+	if strings.Join(refs, ",") != "parse" {
+		t.Errorf("Expected the secret to be read once after the parse. Got %s", refs)
+	}
+	if v, _, _, _ := c.GetStringValue("github", "gh", "token"); v != "parse" {
+		t.Errorf("Expected the token to be read from the source. Got '%s'", v)
+	}
+
+	// --- Setting test context ---
+	c = NewForjCli(kingpinMock.New("Application"))
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewObject("github", "github help", "").
+		AddKey(String, "name", "name help", "[a-z]+", nil).
+		AddField(String, "token", "token help", "[a-z0-9]+", Opts().Secret()).
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("token", nil)
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{"cmd:" + create, "cmd:github", "name", "gh", "token", "after:BAD-Value"}, nil)
+
+	// --- Start testing ---
+	if GetErrorCode(err) != ErrValidationFailed || strings.Contains(err.Error(), "BAD-Value") {
+		t.Errorf("Expected the read secret to be checked and masked. Got '%v'", err)
+	}
+}
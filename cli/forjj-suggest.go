@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxSuggestions is the maximum number of "Did you mean" hints attached to an error.
+const MaxSuggestions = 3
+
+// kingpin builtin flags, not defined in the cli model.
+var builtinFlags = map[string]bool{
+	"help":                   true,
+	"help-long":              true,
+	"help-man":               true,
+	"version":                true,
+	"completion-bash":        true,
+	"completion-script-bash": true,
+	"completion-script-zsh":  true,
+}
+
+// ForjSuggestError is an error about an unknown word (action, object, flag or instance name) with "Did you mean"
+// hints.
+type ForjSuggestError struct {
+	err   error
+	word  string
+	hints []string
+}
+
+func (e *ForjSuggestError) Error() string {
+	quoted := make([]string, 0, len(e.hints))
+	for _, hint := range e.hints {
+		quoted = append(quoted, "'"+hint+"'")
+	}
+	return fmt.Sprintf("%s Did you mean %s?", e.err, strings.Join(quoted, " or "))
+}
+
+// Err return the original error.
+func (e *ForjSuggestError) Err() error {
+	return e.err
+}
+
+// Unwrap return the original error, without hints.
+func (e *ForjSuggestError) Unwrap() error {
+	return e.err
+}
+
+// Word return the unknown word.
+func (e *ForjSuggestError) Word() string {
+	return e.word
+}
+
+// Hints return the suggested words, the closest first.
+func (e *ForjSuggestError) Hints() []string {
+	return e.hints
+}
+
+// GetHints return the "Did you mean" hints attached to err. nil if none.
+func GetHints(err error) []string {
+	if e, ok := err.(*ForjSuggestError); ok {
+		return e.hints
+	}
+	return nil
+}
+
+// withHints attach hints to err. err is returned unchanged if there is no hint.
+func withHints(err error, word string, hints []string) error {
+	if err == nil || len(hints) == 0 {
+		return err
+	}
+	return &ForjSuggestError{err: err, word: word, hints: hints}
+}
+
+// Suggest return the first unknown word found in args (words following the application name) and the closest known
+// words. Actions, objects, object lists, flags and instance flags (--<instance>-<field>) are checked.
+//
+// word is empty if no unknown word has been found.
+func (c *ForjCli) Suggest(args []string) (word string, hints []string) {
+	if c == nil {
+		return
+	}
+	pos := forjCompletion{instances: make(map[*ForjObjectList][]string)}
+	for _, arg := range args {
+		if pos.pending != nil {
+			if fl, ok := pos.pending.(*ForjFlagList); ok {
+				pos.instances[fl.obj] = append(pos.instances[fl.obj], fl.obj.keys(arg)...)
+			}
+			pos.pending = nil
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			if !strings.HasPrefix(arg, "--") || arg == "--" {
+				continue
+			}
+			name := strings.TrimPrefix(arg, "--")
+			has_value := false
+			if i := strings.Index(name, "="); i >= 0 {
+				name = name[:i]
+				has_value = true
+			}
+			if builtinFlags[name] {
+				continue
+			}
+			p := c.completionFlag(pos, name)
+			if p == nil && strings.HasPrefix(name, "no-") {
+				if p = c.completionFlag(pos, name[3:]); p != nil && p.Type() != Bool {
+					p = nil
+				}
+			}
+			if p == nil {
+				return arg, suggestWords(arg, c.suggestFlags(pos))
+			}
+			if !has_value && p.Type() != Bool {
+				pos.pending = p
+			}
+			continue
+		}
+		switch {
+		case pos.action == nil:
+			if a, found := c.actions[arg]; found {
+				pos.action = a
+				pos.params = a.params
+				continue
+			}
+			actions := make([]string, 0, len(c.actions))
+			for name := range c.actions {
+				actions = append(actions, name)
+			}
+			return arg, suggestWords(arg, actions)
+		case pos.object == nil && pos.list == nil:
+			if c.completionCommand(&pos, arg) {
+				continue
+			}
+			if !pos.hasArgs() {
+				return arg, suggestWords(arg, c.suggestObjects(pos.action))
+			}
+			pos.args = append(pos.args, arg)
+		default:
+			if pos.list != nil && len(pos.args) == 0 {
+				pos.instances[pos.list] = append(pos.instances[pos.list], pos.list.keys(arg)...)
+			}
+			pos.args = append(pos.args, arg)
+		}
+	}
+	return "", nil
+}
+
+// suggestError identify the unknown word of a parse error and attach hints, if args contains an unknown word.
+//
+// The error is returned as a ForjUnknownArgError with the word position in args.
+// Typed and aggregated errors are returned unchanged.
+func (c *ForjCli) suggestError(err error, args []string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(ForjErrors); ok || GetErrorCode(err) != "" {
+		return err
+	}
+	word, hints := c.Suggest(args)
+	if word == "" {
+		return err
+	}
+	position := 0
+	for i, arg := range args {
+		if arg == word {
+			position = i
+			break
+		}
+	}
+	return withHints(&ForjUnknownArgError{Arg: word, Position: position, Err: err}, word, hints)
+}
+
+// suggestObjects return objects and object lists commands names of an action.
+func (c *ForjCli) suggestObjects(action *ForjAction) (names []string) {
+	for name, o := range c.objects {
+		if _, found := o.actions[action.name]; found {
+			names = append(names, name)
+		}
+		for _, l := range o.list {
+			if _, found := l.actions[action.name]; found {
+				names = append(names, l.getParamListObjectName())
+			}
+		}
+	}
+	return
+}
+
+// suggestFlags return flags known at the current position, including instance flags of known instances of lists
+// used at this position. (list command or flag list)
+func (c *ForjCli) suggestFlags(pos forjCompletion) []string {
+	flags := c.completeFlags(pos)
+	lists := make(map[*ForjObjectList]bool)
+	if pos.list != nil {
+		lists[pos.list] = true
+	}
+	for _, p := range pos.params {
+		if fl, ok := p.(*ForjFlagList); ok && fl.obj != nil {
+			lists[fl.obj] = true
+		}
+	}
+	for l := range lists {
+		for _, instance := range c.completeInstances(l.obj) {
+			for _, field_name := range l.obj.getInstanceFieldsName(instance) {
+				if !l.isListField(field_name) {
+					flags = append(flags, "--"+instance+"-"+field_name)
+				}
+			}
+		}
+	}
+	return flags
+}
+
+// suggestWords return the candidates close to word, the closest first. Candidates are close if their edit distance
+// to word is at most a third of word length (at least 1). Flags dashes are ignored.
+func suggestWords(word string, candidates []string) []string {
+	name := strings.TrimLeft(word, "-")
+	max := len(name) / 3
+	if max < 1 {
+		max = 1
+	}
+	found := make(map[string]bool)
+	hints := make(forjSuggestions, 0)
+	for _, candidate := range candidates {
+		if candidate == word || found[candidate] {
+			continue
+		}
+		found[candidate] = true
+		if d := editDistance(name, strings.TrimLeft(candidate, "-")); d <= max {
+			hints = append(hints, forjSuggestion{word: candidate, distance: d})
+		}
+	}
+	sort.Sort(hints)
+	ret := make([]string, 0, MaxSuggestions)
+	for _, hint := range hints {
+		if len(ret) == MaxSuggestions {
+			break
+		}
+		ret = append(ret, hint.word)
+	}
+	return ret
+}
+
+// editDistance return the number of inserted, deleted, substituted or transposed characters to transform a into b.
+// (optimal string alignment distance)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// forjSuggestion is a suggested word with its edit distance to the unknown word.
+type forjSuggestion struct {
+	word     string
+	distance int
+}
+
+// forjSuggestions sort suggestions by distance then by name.
+type forjSuggestions []forjSuggestion
+
+func (s forjSuggestions) Len() int      { return len(s) }
+func (s forjSuggestions) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s forjSuggestions) Less(i, j int) bool {
+	if s[i].distance != s[j].distance {
+		return s[i].distance < s[j].distance
+	}
+	return s[i].word < s[j].word
+}
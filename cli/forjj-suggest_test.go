@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"fmt"
+	"forjj-modules/cli/kingpinMock"
+	"strings"
+	"testing"
+)
+
+func TestForjCli_Suggest(t *testing.T) {
+	t.Log("Expect ForjCli_Suggest() to suggest close actions, objects, flags and instance flags.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+
+	tests := []struct {
+		args     []string
+		word     string
+		expected []string
+	}{
+		{[]string{"craete", "repo"}, "craete", []string{create}},
+		{[]string{create, "repoo"}, "repoo", []string{"repo", "repos"}},
+		{[]string{create, "repo", "--titel", "my title"}, "--titel", []string{"--title"}},
+		{[]string{create, "repo", "--debug", "--x", "--kind", "github"}, "", nil},
+		{[]string{create, "repos", "infra", "--infra-titel", "t"}, "--infra-titel", []string{"--infra-title"}},
+		{[]string{create, "repos", "infra", "--infr-title", "t"}, "--infr-title", []string{"--infra-title"}},
+		{[]string{update, "--repos", "app", "--ap-title", "t"}, "--ap-title", []string{"--app-title"}},
+		{[]string{create, "repo", "--help"}, "", nil},
+		{[]string{"unrelated"}, "unrelated", []string{}},
+	}
+
+	for _, test := range tests {
+		// --- Run the test ---
+		word, hints := c.Suggest(test.args)
+
+		// --- Start testing ---
+		if word != test.word {
+			t.Errorf("Expected '%s' unknown word to be '%s'. Got '%s'", strings.Join(test.args, " "), test.word, word)
+		}
+		if strings.Join(hints, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("Expected '%s' to suggest %s. Got %s", strings.Join(test.args, " "), test.expected, hints)
+		}
+	}
+}
+
+func TestForjCli_suggestError(t *testing.T) {
+	t.Log("Expect ForjCli_suggestError() to attach hints to the error.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+	parse_err := fmt.Errorf("expected command but got \"repoo\".")
+
+	// --- Run the test ---
+	err := c.suggestError(parse_err, []string{create, "repoo"})
+
+	// --- Start testing ---
+	if v := strings.Join(GetHints(err), " "); v != "repo repos" {
+		t.Errorf("Expected hints to be 'repo repos'. Got '%s'", v)
+	}
+	if v := err.Error(); v != "expected command but got \"repoo\". Did you mean 'repo' or 'repos'?" {
+		t.Errorf("Expected error with hints. Got '%s'", v)
+	}
+	if e, ok := err.(*ForjSuggestError); !ok || e.Word() != "repoo" {
+		t.Errorf("Expected a ForjSuggestError on 'repoo'. Got '%#v'", err)
+	} else if a, ok := e.Err().(*ForjUnknownArgError); !ok || a.Err != parse_err || a.Position != 1 {
+		t.Errorf("Expected a ForjUnknownArgError at position 1 wrapping the parse error. Got '%#v'", e.Err())
+	}
+	if u, ok := err.(interface {
+		Unwrap() error
+	}); !ok || GetErrorCode(u.Unwrap()) != ErrUnknownArg {
+		t.Errorf("Expected Unwrap() to return the ForjUnknownArgError. Got '%#v'", err)
+	}
+
+	// --- Run the test ---
+	err = c.suggestError(parse_err, []string{create, "repo"})
+
+	// --- Start testing ---
+	if err != parse_err {
+		t.Errorf("Expected the error to be unchanged without unknown word. Got '%s'", err)
+	}
+}
+
+func TestForjCli_getObject_Hints(t *testing.T) {
+	t.Log("Expect ForjCli getters to suggest close objects and actions.")
+
+	// --- Setting test context ---
+	c := NewForjCli(kingpinMock.New("Application"))
+	c.AddFieldListCapture("w", w_f)
+	c.AddAppFlag(String, "debug", "debug help", nil)
+
+	c.NewActions(create, create_help, "create %s", true)
+	c.NewActions(update, update_help, "update %s", true)
+	c.NewObject("repo", "repo help", "").
+		AddKey(String, "name", "name help", "#w", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(String, "kind", "kind help", "", Opts().Enum("github", "gitlab")).
+		AddInstances("infra", "app").
+		DefineActions(create).OnActions().
+		AddFlag("name", nil).
+		AddFlag("title", nil).
+		AddFlag("kind", nil).
+		CreateList("to_create", ",", "name", "repos help").
+		AddActions(create)
+	c.OnActions(update).AddActionFlagFromObjectListAction("repo", "to_create", create)
+
+	// --- Run the test ---
+	_, err := c.getObject("rep")
+	_, err_action := c.getAction("updat")
+
+	// --- Start testing ---
+	if v := strings.Join(GetHints(err), " "); v != "repo" {
+		t.Errorf("Expected object hints to be 'repo'. Got '%s'", v)
+	}
+	if v := strings.Join(GetHints(err_action), " "); v != update {
+		t.Errorf("Expected action hints to be '%s'. Got '%s'", update, v)
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	t.Log("Expect editDistance() to count insertions, deletions, substitutions and transpositions.")
+
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"repo", "repo", 0},
+		{"repoo", "repo", 1},
+		{"titel", "title", 1},
+		{"rpeo", "repo", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, test := range tests {
+		// --- Run the test ---
+		ret := editDistance(test.a, test.b)
+
+		// --- Start testing ---
+		if ret != test.expected {
+			t.Errorf("Expected distance between '%s' and '%s' to be %d. Got %d", test.a, test.b, test.expected, ret)
+		}
+	}
+}
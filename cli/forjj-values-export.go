@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/trace"
+	"gopkg.in/yaml.v2"
+)
+
+// ForjValuesVersion is the version of the value store document format.
+const ForjValuesVersion = 1
+
+// ForjValuesDoc is the serialized form of the ForjCli value store.
+//
+// Ex (yaml):
+//
+//	version: 1
+//	objects:
+//	  repo:
+//	    myrepo:
+//	      action: create
+//	      attributes:
+//	        port:
+//	          type: int
+//	          value: 8080
+//	          origin: {source: default, name: port, raw: "8080"}
+type ForjValuesDoc struct {
+	Version int                                 `json:"version" yaml:"version"`
+	Objects map[string]map[string]ForjRecordDoc `json:"objects" yaml:"objects"` // Records per object name and instance key.
+}
+
+// ForjRecordDoc is the serialized form of an object instance record.
+type ForjRecordDoc struct {
+	Action     string                 `json:"action" yaml:"action"`
+	Attributes map[string]ForjAttrDoc `json:"attributes" yaml:"attributes"`
+}
+
+// ForjAttrDoc is the serialized form of an attribute value.
+//
+// Value is null when the attribute is not set. Duration values are given as string. (ex: 1h30m)
+type ForjAttrDoc struct {
+	Type   string           `json:"type" yaml:"type"`
+	Value  interface{}      `json:"value" yaml:"value"`
+	Origin *ForjValueOrigin `json:"origin,omitempty" yaml:"origin,omitempty"`
+	Secret bool             `json:"secret,omitempty" yaml:"secret,omitempty"` // The value is masked. Not imported.
+}
+
+// ExportValues return the value store as a document.
+func (c *ForjCli) ExportValues() *ForjValuesDoc {
+	doc := &ForjValuesDoc{
+		Version: ForjValuesVersion,
+		Objects: make(map[string]map[string]ForjRecordDoc),
+	}
+	if c == nil {
+		return doc
+	}
+	for object_name, records := range c.values {
+		if records == nil {
+			continue
+		}
+		instances := make(map[string]ForjRecordDoc)
+		for key, data := range records.records {
+			record := ForjRecordDoc{
+				Action:     to_string(data.attrs["action"]),
+				Attributes: make(map[string]ForjAttrDoc),
+			}
+			for attr_name, value := range data.attrs {
+				if attr_name == "action" {
+					continue
+				}
+				attr := ForjAttrDoc{
+					Type:  c.attrType(object_name, key, attr_name, value),
+					Value: exportValue(value),
+				}
+				if data.IsSecret(attr_name) && value != nil {
+					attr.Value = SecretMask
+					attr.Secret = true
+				}
+				if origin, found := data.origins[attr_name]; found {
+					attr.Origin = &origin
+				}
+				record.Attributes[attr_name] = attr
+			}
+			instances[key] = record
+		}
+		doc.Objects[object_name] = instances
+	}
+	return doc
+}
+
+// ExportValuesJSON return the value store as a JSON document.
+func (c *ForjCli) ExportValuesJSON() ([]byte, error) {
+	return json.MarshalIndent(c.ExportValues(), "", "  ")
+}
+
+// ExportValuesYAML return the value store as a YAML document.
+func (c *ForjCli) ExportValuesYAML() ([]byte, error) {
+	return yaml.Marshal(c.ExportValues())
+}
+
+// ImportValues rebuild the value store from a document. Existing values are replaced. Secrets are masked in
+// exported documents and are not imported.
+func (c *ForjCli) ImportValues(doc *ForjValuesDoc) error {
+	if c == nil {
+		return fmt.Errorf("Unable to import values. Cli is nil.")
+	}
+	if doc == nil {
+		return fmt.Errorf("Unable to import values. No document given.")
+	}
+	if doc.Version != ForjValuesVersion {
+		return fmt.Errorf("Unable to import values. Document version %d is not supported. Expect %d.",
+			doc.Version, ForjValuesVersion)
+	}
+
+	values := make(map[string]*ForjRecords)
+	for object_name, instances := range doc.Objects {
+		records := newRecords()
+		for key, record := range instances {
+			data := newData(record.Action)
+			for attr_name, attr := range record.Attributes {
+				if attr.Secret {
+					gotrace.Trace("'%s/%s' attribute '%s' is a masked secret. Not imported.", object_name, key, attr_name)
+					continue
+				}
+				origin := ForjValueOrigin{}
+				if attr.Origin != nil {
+					origin = *attr.Origin
+				}
+				value, err := importValue(attr.Type, attr.Value, origin.IsDefault())
+				if err != nil {
+					return fmt.Errorf("Unable to import '%s/%s' attribute '%s'. %s", object_name, key, attr_name, err)
+				}
+				data.attrs[attr_name] = value
+				if attr.Origin != nil {
+					data.origins[attr_name] = origin
+				}
+			}
+			records.records[key] = data
+		}
+		values[object_name] = records
+	}
+	c.values = values
+	gotrace.Trace("%d objects values imported.", len(values))
+	return nil
+}
+
+// ImportValuesJSON rebuild the value store from a JSON document.
+func (c *ForjCli) ImportValuesJSON(data []byte) error {
+	doc := new(ForjValuesDoc)
+	if err := json.Unmarshal(data, doc); err != nil {
+		return fmt.Errorf("Unable to read JSON values document. %s", err)
+	}
+	return c.ImportValues(doc)
+}
+
+// ImportValuesYAML rebuild the value store from a YAML document.
+func (c *ForjCli) ImportValuesYAML(data []byte) error {
+	doc := new(ForjValuesDoc)
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return fmt.Errorf("Unable to read YAML values document. %s", err)
+	}
+	return c.ImportValues(doc)
+}
+
+// attrType return the declared type of an attribute. If not declared, the type is guessed from the value.
+func (c *ForjCli) attrType(object, instance, attr string, value interface{}) string {
+	if object == internal_app {
+		if f, found := c.flags[attr]; found {
+			return f.value_type
+		}
+	}
+	if o, found := c.objects[object]; found {
+		if f, found := o.fields[attr]; found {
+			return f.value_type
+		}
+		if i, found := o.instances[instance]; found {
+			if f, found := i.additional_fields[attr]; found {
+				return f.value_type
+			}
+		}
+	}
+	return valueType(value)
+}
+
+// valueType guess the param type from a stored value.
+func valueType(value interface{}) string {
+	if v, _, ok := deref_value(value); ok {
+		value = v
+	}
+	switch value.(type) {
+	case bool:
+		return Bool
+	case int:
+		return Int
+	case float64:
+		return Float
+	case time.Duration:
+		return Duration
+	case []string:
+		return StringSlice
+	case map[string]string:
+		return StringMap
+	}
+	return String
+}
+
+// exportValue return a stored value as a serializable value. Defaults addresses are dereferenced.
+func exportValue(value interface{}) interface{} {
+	if v, ok := value.(*interface{}); ok {
+		value = *v
+	}
+	if v, _, ok := deref_value(value); ok {
+		value = v
+	}
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+	return value
+}
+
+// importValue convert a decoded document value to the attribute type.
+//
+// Default values are returned by address, as stored by the cli.
+func importValue(atype string, value interface{}, is_default bool) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	var ret interface{}
+	switch atype {
+	case Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'%v' is not a boolean.", value)
+		}
+		if is_default {
+			return &b, nil
+		}
+		return b, nil
+	case StringSlice:
+		switch list := value.(type) {
+		case []interface{}:
+			values := make([]string, len(list))
+			for i, v := range list {
+				values[i] = fmt.Sprintf("%v", v)
+			}
+			ret = values
+		case string: // Comma separated list
+			ret = list
+		default:
+			return nil, fmt.Errorf("'%v' is not a list.", value)
+		}
+	case StringMap:
+		switch m := value.(type) {
+		case map[string]interface{}: // JSON
+			values := make(map[string]string)
+			for k, v := range m {
+				values[k] = fmt.Sprintf("%v", v)
+			}
+			ret = values
+		case map[interface{}]interface{}: // YAML
+			values := make(map[string]string)
+			for k, v := range m {
+				values[fmt.Sprintf("%v", k)] = fmt.Sprintf("%v", v)
+			}
+			ret = values
+		case string: // Comma separated list of key=value
+			ret = m
+		default:
+			return nil, fmt.Errorf("'%v' is not a map.", value)
+		}
+	default:
+		ret = fmt.Sprintf("%v", value)
+		if atype == Int {
+			if f, ok := value.(float64); ok { // JSON numbers are float64.
+				ret = fmt.Sprintf("%d", int64(f))
+			}
+		}
+	}
+
+	if s, ok := ret.(string); ok && is_default {
+		return toTypedValue(atype, &s)
+	}
+	v, err := toTypedValue(atype, ret)
+	if err != nil || !is_default {
+		return v, err
+	}
+	switch v := v.(type) {
+	case []string:
+		return &v, nil
+	case map[string]string:
+		return &v, nil
+	}
+	return v, nil
+}
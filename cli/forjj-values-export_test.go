@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestForjCli_ExportValues(t *testing.T) {
+	t.Log("Expect ForjCli_ExportValues() to export all records with types and origins.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd  = "cmd:"
+		c_repo = "repo"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(Int, "port", "port help", "", Opts().Default("8080")).
+		AddField(Bool, "private", "private help", "", nil).
+		AddField(Duration, "timeout", "timeout help", "", Opts().Default("1m")).
+		AddField(StringSlice, "labels", "labels help", "", nil).
+		AddField(StringMap, "vars", "vars help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("port", nil).
+		AddFlag("private", nil).
+		AddFlag("timeout", nil).
+		AddFlag("labels", nil).
+		AddFlag("vars", nil)
+	if err := c.GetObject(c_repo).Error(); err != nil {
+		t.Errorf("Expected object declaration to work. %s", err)
+		return
+	}
+
+	if _, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo", "title", "My repo",
+		"private", "true", "labels", "bug", "labels", "feature", "vars", "k1=v1"}, nil); err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+
+	// --- Run the test ---
+	doc := c.ExportValues()
+
+	// --- Start testing ---
+	if doc.Version != ForjValuesVersion {
+		t.Errorf("Expected document version %d. Got %d", ForjValuesVersion, doc.Version)
+	}
+	record, found := doc.Objects["repo"]["myrepo"]
+	if !found {
+		t.Errorf("Expected 'repo/myrepo' record to be exported. Got %#v", doc.Objects)
+		return
+	}
+	if record.Action != create {
+		t.Errorf("Expected record action '%s'. Got '%s'", create, record.Action)
+	}
+	if v := record.Attributes["port"]; v.Type != Int || v.Value != 8080 || v.Origin == nil || !v.Origin.IsDefault() {
+		t.Errorf("Expected port to be exported as default int 8080. Got %#v", v)
+	}
+	if v := record.Attributes["timeout"]; v.Value != "1m0s" {
+		t.Errorf("Expected timeout to be exported as '1m0s'. Got %#v", v.Value)
+	}
+	if v := record.Attributes["title"]; v.Value != "My repo" || v.Origin == nil || v.Origin.Source != SourceCli {
+		t.Errorf("Expected title to be exported from cli. Got %#v", v)
+	}
+}
+
+func TestForjCli_ImportValues(t *testing.T) {
+	t.Log("Expect ForjCli_ImportValuesJSON/YAML() to rebuild an exported value store.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd  = "cmd:"
+		c_repo = "repo"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(Int, "port", "port help", "", Opts().Default("8080")).
+		AddField(Bool, "private", "private help", "", nil).
+		AddField(Duration, "timeout", "timeout help", "", Opts().Default("1m")).
+		AddField(StringSlice, "labels", "labels help", "", nil).
+		AddField(StringMap, "vars", "vars help", "", nil).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("port", nil).
+		AddFlag("private", nil).
+		AddFlag("timeout", nil).
+		AddFlag("labels", nil).
+		AddFlag("vars", nil)
+	if err := c.GetObject(c_repo).Error(); err != nil {
+		t.Errorf("Expected object declaration to work. %s", err)
+		return
+	}
+
+	if _, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo", "title", "My repo",
+		"private", "true", "labels", "bug", "labels", "feature", "vars", "k1=v1"}, nil); err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	json_doc, err := c.ExportValuesJSON()
+	if err != nil {
+		t.Errorf("Expected ExportValuesJSON() to work. Got '%s'", err)
+		return
+	}
+	yaml_doc, err := c.ExportValuesYAML()
+	if err != nil {
+		t.Errorf("Expected ExportValuesYAML() to work. Got '%s'", err)
+		return
+	}
+
+	imports := map[string]func(*ForjCli) error{
+		"json": func(c *ForjCli) error { return c.ImportValuesJSON(json_doc) },
+		"yaml": func(c *ForjCli) error { return c.ImportValuesYAML(yaml_doc) },
+	}
+	for format, import_values := range imports {
+		c2 := NewForjCli(kingpinMock.New("Application"))
+
+		// --- Run the test ---
+		err := import_values(c2)
+
+		// --- Start testing ---
+		if err != nil {
+			t.Errorf("Expected %s import to work. Got '%s'", format, err)
+			continue
+		}
+		if v, found, isDefault, _ := c2.GetStringValue("repo", "myrepo", "title"); !found || v != "My repo" || isDefault {
+			t.Errorf("Expected %s title to be 'My repo'. Got '%s'", format, v)
+		}
+		if v, found, _ := c2.GetIntValue("repo", "myrepo", "port"); !found || v != 8080 {
+			t.Errorf("Expected %s port to be 8080. Got %d", format, v)
+		}
+		if v, _, _ := c2.GetDurationValue("repo", "myrepo", "timeout"); v != time.Minute {
+			t.Errorf("Expected %s timeout to be 1m. Got %s", format, v)
+		}
+		if v, _, _ := c2.GetBoolValue("repo", "myrepo", "private"); !v {
+			t.Errorf("Expected %s private to be true.", format)
+		}
+		if v, _, _ := c2.GetStringSliceValue("repo", "myrepo", "labels"); !reflect.DeepEqual(v, []string{"bug", "feature"}) {
+			t.Errorf("Expected %s labels to be 'bug, feature'. Got %s", format, v)
+		}
+		if v, _, _ := c2.GetStringMapValue("repo", "myrepo", "vars"); !reflect.DeepEqual(v, map[string]string{"k1": "v1"}) {
+			t.Errorf("Expected %s vars to be 'k1=v1'. Got %s", format, v)
+		}
+		if o, _, _ := c2.GetValueOrigin("repo", "myrepo", "port"); !o.IsDefault() || o.Raw != "8080" {
+			t.Errorf("Expected %s port origin to be default. Got %#v", format, o)
+		}
+		if !reflect.DeepEqual(c2.ExportValues(), c.ExportValues()) {
+			t.Errorf("Expected %s imported store to export identically.", format)
+		}
+	}
+
+	// --- Run the test ---
+	err = c.ImportValuesJSON([]byte(strings.Replace(string(json_doc), `"version": 1`, `"version": 2`, 1)))
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected ImportValuesJSON() to fail on unsupported version. Got no error.")
+	}
+}
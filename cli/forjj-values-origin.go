@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/trace"
+)
+
+// Value origin sources.
+const (
+	SourceCli      = "cli"      // Value given on the command line.
+	SourceEnvar    = "envar"    // Value given by an environment variable.
+	SourceDefault  = "default"  // Declared default value.
+	SourceHook     = "hook"     // Value set by the application or a hook with SetValue.
+	SourceFile     = "file"     // Value loaded from a configuration file.
+	SourcePrompt   = "prompt"   // Value asked to the user in interactive mode.
+	SourceProvider = "provider" // Secret value given by the secret provider. (See ForjCli.SecretProvider)
+)
+
+// ForjValueOrigin describe where an attribute value comes from.
+type ForjValueOrigin struct {
+	Source string `json:"source" yaml:"source"`                 // One of Source* constants
+	Name   string `json:"name,omitempty" yaml:"name,omitempty"` // Flag/arg name (cli), environment variable name (envar) or file name (file).
+	Raw    string `json:"raw" yaml:"raw"`                       // Original string value, before conversion to the attribute type. SecretMask for a secret.
+
+	secret bool // true if the value is a secret. (See ForjOpts.Secret)
+}
+
+// String return a short description of the origin. Ex: cli, envar 'FORJJ_INFRA', file 'forjj.yaml'
+func (o ForjValueOrigin) String() string {
+	switch o.Source {
+	case SourceEnvar, SourceFile:
+		return o.Source + " '" + o.Name + "'"
+	case "":
+		return "unknown"
+	}
+	return o.Source
+}
+
+// IsDefault return true if the value comes from the declared default.
+func (o ForjValueOrigin) IsDefault() bool {
+	return o.Source == SourceDefault
+}
+
+// rawString return the original string representation of a value.
+//
+// Repeatable values are joined with ','.
+func rawString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case *interface{}:
+		if v == nil {
+			return ""
+		}
+		return rawString(*v)
+	case []string:
+		return strings.Join(v, ",")
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			keys[i] = key + "=" + v[key]
+		}
+		return strings.Join(keys, ",")
+	case *url.URL:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	}
+	if v, _, ok := deref_value(value); ok {
+		return rawString(v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// paramOrigin return the origin of a param context value, as reported by the cli backend.
+func paramOrigin(context clier.ParseContexter, p ForjParam, value interface{}) (origin ForjValueOrigin) {
+	origin.Raw = rawString(value)
+	origin.Name = p.Name()
+	switch param := p.(type) {
+	case *ForjFlag:
+		origin.Source = param.getContextSource(context)
+		if origin.Source == clier.SourceEnvar {
+			origin.Name = param.getEnvar()
+		}
+		if origin.IsDefault() {
+			if o := param.options.defaultOrigin(); o.Source == SourceFile {
+				o.Raw = origin.Raw
+				origin = o
+			}
+		}
+	case *ForjArg:
+		origin.Source = param.getContextSource(context)
+	}
+	if origin.Source == "" {
+		// No value in the context: the attribute keeps its zero value.
+		origin.Source = SourceDefault
+	}
+	origin.secret = paramSecret(p)
+	return
+}
+
+// updatedValueOrigin identify the origin of a param value updating an object instance attribute.
+//
+// If the attribute already has the same raw value, the recorded origin is kept. Ex: a prompted or hook value.
+func (c *ForjCli) updatedValueOrigin(object, instance, field string, p ForjParam, value interface{}) ForjValueOrigin {
+	origin := paramOrigin(c.cli_context.context, p, value)
+	if o, found, _ := c.GetValueOrigin(object, instance, field); found && o.Raw == origin.Raw {
+		return o
+	}
+	return origin
+}
+
+// GetOrigin return the origin of an attribute value.
+func (d *ForjData) GetOrigin(param string) (origin ForjValueOrigin, found bool) {
+	if d == nil {
+		return
+	}
+	origin, found = d.origins[param]
+	return
+}
+
+// IsDefault return true if the attribute value comes from the declared default.
+func (d *ForjData) IsDefault(param string) bool {
+	origin, _ := d.GetOrigin(param)
+	return origin.IsDefault()
+}
+
+// IsSecret return true if the attribute value is a secret. (See ForjOpts.Secret)
+func (d *ForjData) IsSecret(param string) bool {
+	origin, _ := d.GetOrigin(param)
+	return origin.secret
+}
+
+// GetValueOrigin return the origin of an object instance attribute value.
+func (c *ForjCli) GetValueOrigin(object, key, param string) (ForjValueOrigin, bool, error) {
+	r, found := c.values[object]
+	if !found {
+		return ForjValueOrigin{}, false, fmt.Errorf("Unable to find object '%s'", object)
+	}
+	d, found := r.records[key]
+	if !found {
+		return ForjValueOrigin{}, false, fmt.Errorf("Unable to find record identified by key '%s'", key)
+	}
+	origin, found := d.GetOrigin(param)
+	return origin, found, nil
+}
+
+// SetValueFrom set an object instance attribute value, recording its origin.
+//
+// Ex: a configuration file loader use it with ForjValueOrigin{Source: SourceFile, Name: <file>}
+// If origin.Raw is empty, it is built from the value.
+func (c *ForjCli) SetValueFrom(object, instance, atype, attr string, value interface{}, origin ForjValueOrigin) (err error) {
+	if origin.Raw == "" {
+		origin.Raw = rawString(value)
+	}
+	if c.isSecretField(object, instance, attr) {
+		origin.secret = true
+	}
+	r := c.values[object]
+	if r, err = r.set(instance, atype, attr, value, origin); err != nil {
+		return err
+	}
+	c.values[object] = r
+	gotrace.Trace("Added instance attribute '%s/%s' to object '%s' from %s", instance, attr, object, origin)
+	return nil
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"forjj-modules/cli/kingpinMock"
+	"os"
+	"testing"
+)
+
+func TestForjCli_Parse_ValueOrigin(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to record the origin of each object attribute value.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd  = "cmd:"
+		c_repo = "repo"
+	)
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	c.NewActions(create, create_help, "", false)
+	c.NewObject(c_repo, "repo help", "").
+		AddKey(String, "name", "name help", "", nil).
+		AddField(String, "title", "title help", "", nil).
+		AddField(Int, "port", "port help", "", Opts().Default("8080")).
+		DefineActions(create).OnActions().
+		AddFlag("name", Opts().Required()).
+		AddFlag("title", nil).
+		AddFlag("port", nil)
+	if err := c.GetObject(c_repo).Error(); err != nil {
+		t.Errorf("Expected object declaration to work. %s", err)
+		return
+	}
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo", "title", "My repo"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if o, found, err := c.GetValueOrigin(c_repo, "myrepo", "title"); err != nil || !found {
+		t.Errorf("Expected title origin to be found. Got %t, %s", found, err)
+	} else if o.Source != SourceCli || o.Name != "title" || o.Raw != "My repo" {
+		t.Errorf("Expected title to come from cli flag 'title' with 'My repo'. Got %#v", o)
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "port"); o.Source != SourceDefault || o.Raw != "8080" {
+		t.Errorf("Expected port to come from default '8080'. Got %#v", o)
+	}
+	if data := c.GetObjectValues(c_repo)["myrepo"]; !data.IsDefault("port") || data.IsDefault("title") {
+		t.Error("Expected ForjData.IsDefault() to be true for port only.")
+	}
+
+	// --- Run the test ---
+	err = c.SetValue(c_repo, "myrepo", String, "title", "Updated")
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected SetValue() to work. Got '%s'", err)
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "title"); o.Source != SourceHook || o.Raw != "Updated" {
+		t.Errorf("Expected title to come from a hook. Got %#v", o)
+	}
+
+	// --- Run the test ---
+	err = c.SetValueFrom(c_repo, "myrepo", Int, "port", "9090",
+		ForjValueOrigin{Source: SourceFile, Name: "forjj.yaml"})
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected SetValueFrom() to work. Got '%s'", err)
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "port"); o.String() != "file 'forjj.yaml'" || o.Raw != "9090" {
+		t.Errorf("Expected port to come from file 'forjj.yaml'. Got %#v", o)
+	}
+
+	// --- Run the test ---
+	err = c.SetValueFrom(c_repo, "myrepo", Int, "port", "abc",
+		ForjValueOrigin{Source: SourceFile, Name: "bad.yaml"})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected SetValueFrom() to fail on an invalid int. Got no error.")
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "port"); o.String() != "file 'forjj.yaml'" || o.Raw != "9090" {
+		t.Errorf("Expected port origin to be kept on invalid values. Got %#v", o)
+	}
+	if _, _, err := c.GetValueOrigin(c_repo, "unknown", "port"); err == nil {
+		t.Error("Expected GetValueOrigin() to fail on unknown instance. Got no error.")
+	}
+}
+
+func TestForjObject_AddField_SingleEnvarOrigin(t *testing.T) {
+	t.Log("Expect single object fields set from envar to have an envar origin.")
+
+	// --- Setting test context ---
+	const envar = "FORJJ_CLI_TEST_WORKSPACE_ORIGIN"
+	os.Setenv(envar, "/tmp/ws")
+	defer os.Unsetenv(envar)
+
+	app := kingpinMock.New("Application")
+	c := NewForjCli(app)
+
+	// --- Run the test ---
+	c.NewObject(workspace, "workspace help", "").Single().
+		AddField(String, "path", "path help", "", Opts().Envar(envar).Default("/tmp/ws")).
+		AddField(String, "docker", "docker help", "", Opts().Default("/tmp/docker"))
+
+	// --- Start testing ---
+	if o, found, _ := c.GetValueOrigin(workspace, workspace, "path"); !found || o.Source != SourceEnvar ||
+		o.String() != "envar '"+envar+"'" {
+		t.Errorf("Expected path to come from envar '%s'. Got %#v", envar, o)
+	}
+	if o, _, _ := c.GetValueOrigin(workspace, workspace, "docker"); o.Source != SourceDefault || o.Raw != "/tmp/docker" {
+		t.Errorf("Expected docker to come from default. Got %#v", o)
+	}
+}
+
+func TestForjCli_Parse_ValueOriginFromBackend(t *testing.T) {
+	t.Log("Expect ForjCli_Parse() to record the value source reported by the backend, even if the envar has the same value.")
+
+	// --- Setting test context ---
+	const (
+		c_cmd  = "cmd:"
+		c_repo = "repo"
+		envar  = "FORJJ_CLI_TEST_TITLE_ORIGIN"
+	)
+	os.Setenv(envar, "foo")
+	defer os.Unsetenv(envar)
+
+	newCli := func() *ForjCli {
+		c := NewForjCli(kingpinMock.New("Application"))
+		c.NewActions(create, create_help, "", false)
+		c.NewObject(c_repo, "repo help", "").
+			AddKey(String, "name", "name help", "", nil).
+			AddField(String, "title", "title help", "", Opts().Envar(envar)).
+			DefineActions(create).OnActions().
+			AddFlag("name", Opts().Required()).
+			AddFlag("title", nil)
+		return c
+	}
+	c := newCli()
+
+	// --- Run the test ---
+	_, err := c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo", "title", "foo"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "title"); o.Source != SourceCli || o.Raw != "foo" {
+		t.Errorf("Expected title to come from cli. Got %#v", o)
+	}
+
+	// --- Setting test context ---
+	c = newCli()
+
+	// --- Run the test ---
+	_, err = c.Parse([]string{c_cmd + create, c_cmd + c_repo, "name", "myrepo"}, nil)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got '%s'", err)
+		return
+	}
+	if o, _, _ := c.GetValueOrigin(c_repo, "myrepo", "title"); o.String() != "envar '"+envar+"'" || o.Raw != "foo" {
+		t.Errorf("Expected title to come from envar '%s'. Got %#v", envar, o)
+	}
+}
@@ -3,17 +3,14 @@ package cli
 import (
 	"fmt"
 	"github.com/forj-oss/forjj-modules/trace"
+	"net/url"
 	"strconv"
+	"time"
 )
 
+// SetValue set an object instance attribute value. The value origin is recorded as set by a hook.
 func (c *ForjCli) SetValue(object, instance, atype, attr string, value interface{}) (err error) {
-	r := c.values[object]
-	if r, err = r.set(instance, atype, attr, value); err != nil {
-		return err
-	}
-	c.values[object] = r
-	gotrace.Trace("Added instance attribute '%s/%s' to object '%s'", instance, attr, object)
-	return nil
+	return c.SetValueFrom(object, instance, atype, attr, value, ForjValueOrigin{Source: SourceHook})
 }
 
 func (c *ForjCli) setObjectAttributes(action, object, key string) (d *ForjData) {
@@ -36,9 +33,12 @@ func (c *ForjCli) setObjectAttributes(action, object, key string) (d *ForjData)
 			d.attrs["action"] = action
 		}
 		if d.attrs["action"] != action && action != "setup" {
-			c.err = fmt.Errorf("Unable to %s AND %s attribute at the same time. "+
-				"Please remove %s to one of the 2 different action and retry",
-				d.attrs["action"], action, object)
+			c.err = &ForjConflictingActionError{
+				Object:   object,
+				Instance: key,
+				Action:   action,
+				Current:  to_string(d.attrs["action"]),
+			}
 			return nil
 		}
 	}
@@ -64,13 +64,23 @@ func (r *ForjRecords) String() (ret string) {
 				ret += fmt.Sprintf("        %s : Not defined\n", attr_name)
 				continue
 			}
+			if record.IsSecret(attr_name) {
+				ret += fmt.Sprintf("        %s : %s (secret)\n", attr_name, SecretMask)
+				continue
+			}
 			if v, ok := attr_value.(string); ok {
 				ret += fmt.Sprintf("        %s : %s\n", attr_name, v)
 				continue
 			}
 			if v, ok := attr_value.(*string); ok {
 				ret += fmt.Sprintf("        %s : %s (%p) - Default\n", attr_name, *v, v)
+				continue
 			}
+			if origin, found := record.origins[attr_name]; found {
+				ret += fmt.Sprintf("        %s : %v (%s)\n", attr_name, attr_value, origin)
+				continue
+			}
+			ret += fmt.Sprintf("        %s : %v\n", attr_name, attr_value)
 		}
 	}
 	return
@@ -106,12 +116,12 @@ func (r *ForjRecords) Get(key, param string) (ret interface{}, found bool, err e
 	return
 }
 
-func (r *ForjRecords) set(instance, atype, attr string, value interface{}) (_ *ForjRecords, err error) {
+func (r *ForjRecords) set(instance, atype, attr string, value interface{}, origin ForjValueOrigin) (_ *ForjRecords, err error) {
 	if r == nil {
 		r = newRecords()
 	}
 	i := r.records[instance]
-	if i, err = i.set(atype, attr, value); err != nil {
+	if i, err = i.setFrom(atype, attr, value, origin); err != nil {
 		return nil, err
 	}
 	r.records[instance] = i
@@ -120,15 +130,17 @@ func (r *ForjRecords) set(instance, atype, attr string, value interface{}) (_ *F
 }
 
 type ForjData struct {
-	attrs map[string]interface{} // Collection of Values per Attribute Name.
+	attrs   map[string]interface{}     // Collection of Values per Attribute Name.
+	origins map[string]ForjValueOrigin // Where each attribute value comes from.
 	//instance_attrs map[string]ForjInstanceData
 }
 
 func newData(defaut_action string) (r *ForjData) {
 	r = new(ForjData)
 	r.attrs = make(map[string]interface{})
+	r.origins = make(map[string]ForjValueOrigin)
 	//r.instance_attrs = make(map[string]ForjInstanceData)
-	r.set(String, "action", defaut_action)
+	r.setFrom(String, "action", defaut_action, ForjValueOrigin{Source: SourceDefault})
 	return
 }
 
@@ -148,10 +160,33 @@ func (r *ForjData) Keys() (keys []string) {
 	return
 }
 
+// set store an attribute value given on the command line.
 func (d *ForjData) set(atype, key string, value interface{}) (*ForjData, error) {
+	return d.setFrom(atype, key, value, ForjValueOrigin{})
+}
+
+// setFrom store an attribute value with its origin.
+func (d *ForjData) setFrom(atype, key string, value interface{}, origin ForjValueOrigin) (*ForjData, error) {
 	if d == nil {
 		d = newData("setup") // default action
 	}
+	if origin.Source == "" {
+		origin.Source = SourceCli
+	}
+	if origin.Raw == "" {
+		origin.Raw = rawString(value)
+	}
+	if origin.secret {
+		// Registered before any trace.
+		addSecret(origin.Raw)
+		addSecret(rawString(value))
+		origin.Raw = SecretMask
+	}
+	if d.origins == nil {
+		d.origins = make(map[string]ForjValueOrigin)
+	}
+
+	// The origin is recorded only when the value has been stored.
 	switch atype {
 	case String:
 		d.attrs[key] = value
@@ -170,6 +205,7 @@ func (d *ForjData) set(atype, key string, value interface{}) (*ForjData, error)
 			str = value.(string)
 		case bool, *bool:
 			d.attrs[key] = value
+			d.origins[key] = origin
 			return d, nil
 		}
 
@@ -179,7 +215,16 @@ func (d *ForjData) set(atype, key string, value interface{}) (*ForjData, error)
 			d.attrs[key] = b
 			gotrace.Trace("Added attribute '%s' value '%t'", key, b)
 		}
+
+	case Int, Float, Duration, Enum, URL, Path, StringSlice, StringMap:
+		if v, err := toTypedValue(atype, value); err != nil {
+			return nil, err
+		} else {
+			d.attrs[key] = v
+			gotrace.Trace("Added attribute '%s' %s value '%v'", key, atype, v)
+		}
 	}
+	d.origins[key] = origin
 	return d, nil
 }
 
@@ -197,6 +242,54 @@ func (d *ForjData) GetString(param string) (ret string) {
 	return
 }
 
+// GetInt return the attribute value as int. 0 if not found or not an integer.
+func (d *ForjData) GetInt(param string) (ret int) {
+	if v, _, err := d.Get(param); err == nil {
+		ret = to_int(v)
+	}
+	return
+}
+
+// GetFloat return the attribute value as float64. 0 if not found or not a number.
+func (d *ForjData) GetFloat(param string) (ret float64) {
+	if v, _, err := d.Get(param); err == nil {
+		ret = to_float(v)
+	}
+	return
+}
+
+// GetDuration return the attribute value as time.Duration. 0 if not found or not a duration.
+func (d *ForjData) GetDuration(param string) (ret time.Duration) {
+	if v, _, err := d.Get(param); err == nil {
+		ret = to_duration(v)
+	}
+	return
+}
+
+// GetURL return the attribute value as *url.URL. nil if not found or not an URL.
+func (d *ForjData) GetURL(param string) (ret *url.URL) {
+	if v, _, err := d.Get(param); err == nil {
+		ret = to_url(v)
+	}
+	return
+}
+
+// GetStringSlice return the attribute value as []string. nil if not found or not a list.
+func (d *ForjData) GetStringSlice(param string) (ret []string) {
+	if v, _, err := d.Get(param); err == nil {
+		ret = to_string_slice(v)
+	}
+	return
+}
+
+// GetStringMap return the attribute value as map[string]string. nil if not found or not a map.
+func (d *ForjData) GetStringMap(param string) (ret map[string]string) {
+	if v, _, err := d.Get(param); err == nil {
+		ret = to_string_map(v)
+	}
+	return
+}
+
 func (d *ForjData) Get(param string) (ret interface{}, found bool, err error) {
 	if v, isfound := d.attrs[param]; isfound {
 		ret = v
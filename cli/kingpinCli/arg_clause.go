@@ -2,6 +2,8 @@ package kingpinCli
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/alecthomas/kingpin"
 	"github.com/forj-oss/forjj-modules/cli/clier"
@@ -35,6 +37,26 @@ func (a *ArgClause) Bool() *bool {
 	return a.arg.Bool()
 }
 
+func (a *ArgClause) Int() *int {
+	return a.arg.Int()
+}
+
+func (a *ArgClause) Float64() *float64 {
+	return a.arg.Float64()
+}
+
+func (a *ArgClause) Duration() *time.Duration {
+	return a.arg.Duration()
+}
+
+func (a *ArgClause) Enum(options ...string) *string {
+	return a.arg.Enum(options...)
+}
+
+func (a *ArgClause) URL() **url.URL {
+	return a.arg.URL()
+}
+
 func (a *ArgClause) Required() clier.ArgClauser {
 	a.arg.Required()
 	return a
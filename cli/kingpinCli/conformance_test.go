@@ -0,0 +1,17 @@
+package kingpinCli
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/cli/clier/cliertest"
+)
+
+func TestConformance(t *testing.T) {
+	t.Log("Expect kingpinCli to pass the clier conformance suite.")
+	cliertest.RunConformance(t, cliertest.ConformanceBackend{
+		New:  func() clier.Applicationer { return New(kingpin.New("test", ""), "myapp") },
+		Args: cliertest.CommandLineArgs,
+	})
+}
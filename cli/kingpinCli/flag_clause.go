@@ -2,6 +2,10 @@ package kingpinCli
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/alecthomas/kingpin"
 	"github.com/forj-oss/forjj-modules/cli/clier"
 )
@@ -13,6 +17,7 @@ type KFlagClause interface {
 type FlagClause struct {
 	flag          *kingpin.FlagClause
 	default_value *string
+	multi         bool // true if the flag is repeatable. (Strings, StringMap)
 }
 
 func (a *FlagClause) Stringer() string {
@@ -34,6 +39,40 @@ func (f *FlagClause) Bool() *bool {
 	return f.flag.Bool()
 }
 
+func (f *FlagClause) Int() *int {
+	return f.flag.Int()
+}
+
+func (f *FlagClause) Float64() *float64 {
+	return f.flag.Float64()
+}
+
+func (f *FlagClause) Duration() *time.Duration {
+	return f.flag.Duration()
+}
+
+func (f *FlagClause) Enum(options ...string) *string {
+	return f.flag.Enum(options...)
+}
+
+func (f *FlagClause) URL() **url.URL {
+	return f.flag.URL()
+}
+
+func (f *FlagClause) Strings() *[]string {
+	f.multi = true
+	return f.flag.Strings()
+}
+
+func (f *FlagClause) StringMap() *map[string]string {
+	f.multi = true
+	return f.flag.StringMap()
+}
+
+func (f *FlagClause) isMulti() bool {
+	return f.multi
+}
+
 func (f *FlagClause) Required() clier.FlagClauser {
 	f.flag.Required()
 	return f
@@ -53,7 +92,12 @@ func (f *FlagClause) Default(p1 string) clier.FlagClauser {
 	if f.default_value == nil {
 		f.default_value = new(string)
 	}
-	f.flag.Default(p1)
+	if f.multi {
+		// Repeatable flags default values are comma separated.
+		f.flag.Default(strings.Split(p1, ",")...)
+	} else {
+		f.flag.Default(p1)
+	}
 	*f.default_value = p1
 	return f
 }
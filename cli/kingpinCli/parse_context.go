@@ -22,38 +22,85 @@ func (p *ParseContext) IsInvalidContext() bool {
 
 // GetArgValue get value from cli, or if missing, ENV or if missing, defaults
 func (p *ParseContext) GetArgValue(a clier.ArgClauser) (interface{}, bool) {
+	v, source := p.getArgValue(a)
+	return v, source != ""
+}
+
+// GetArgSource return the source of the arg value. (See clier.ParseContexter)
+func (p *ParseContext) GetArgSource(a clier.ArgClauser) string {
+	_, source := p.getArgValue(a)
+	return source
+}
+
+func (p *ParseContext) getArgValue(a clier.ArgClauser) (interface{}, string) {
 	karg := a.(KArgClause).GetArg()
 	argClause := a.(*ArgClause)
 	for _, element := range p.context.Elements {
 		if a, ok := element.Clause.(*kingpin.ArgClause); ok && a == karg {
-			return *element.Value, true
+			return *element.Value, clier.SourceCli
 		}
 	}
 	if karg.HasEnvarValue() {
-		return karg.GetEnvarValue(), true
+		return karg.GetEnvarValue(), clier.SourceEnvar
 	}
 	if argClause.hasDefaults() {
-		return argClause.getDefaults(), true
+		return argClause.getDefaults(), clier.SourceDefault
 	}
-	return nil, false
+	return nil, ""
 }
 
 // GetFlagValue get value from cli, or if missing, ENV or if missing, defaults
+//
+// Repeatable flags (Strings, StringMap) values are returned as []string. Their defaults are kept as *string.
 func (p *ParseContext) GetFlagValue(f clier.FlagClauser) (interface{}, bool) {
+	v, source := p.getFlagValue(f)
+	return v, source != ""
+}
+
+// GetFlagSource return the source of the flag value. (See clier.ParseContexter)
+func (p *ParseContext) GetFlagSource(f clier.FlagClauser) string {
+	_, source := p.getFlagValue(f)
+	return source
+}
+
+func (p *ParseContext) getFlagValue(f clier.FlagClauser) (interface{}, string) {
 	kflag := f.(KFlagClause).GetFlag()
 	flagClause := f.(*FlagClause)
+	if flagClause.isMulti() {
+		return p.getMultiFlagValue(kflag, flagClause)
+	}
+	for _, element := range p.context.Elements {
+		if f, ok := element.Clause.(*kingpin.FlagClause); ok && f == kflag {
+			return *element.Value, clier.SourceCli
+		}
+	}
+	if kflag.HasEnvarValue() {
+		return kflag.GetEnvarValue(), clier.SourceEnvar
+	}
+	if flagClause.hasDefaults() {
+		return flagClause.getDefaults(), clier.SourceDefault
+	}
+	return nil, ""
+}
+
+// getMultiFlagValue get all values of a repeatable flag from cli, or if missing, ENV or if missing, defaults
+func (p *ParseContext) getMultiFlagValue(kflag *kingpin.FlagClause, flagClause *FlagClause) (interface{}, string) {
+	values := make([]string, 0)
 	for _, element := range p.context.Elements {
 		if f, ok := element.Clause.(*kingpin.FlagClause); ok && f == kflag {
-			return *element.Value, true
+			values = append(values, *element.Value)
 		}
 	}
+	if len(values) > 0 {
+		return values, clier.SourceCli
+	}
 	if kflag.HasEnvarValue() {
-		return kflag.GetEnvarValue(), true
+		return clier.EnvarValues(kflag.GetEnvarValue()), clier.SourceEnvar
 	}
 	if flagClause.hasDefaults() {
-		return flagClause.getDefaults(), true
+		return flagClause.getDefaults(), clier.SourceDefault
 	}
-	return nil, false
+	return nil, ""
 }
 
 func (p *ParseContext) SelectedCommands() (res []clier.CmdClauser) {
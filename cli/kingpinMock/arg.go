@@ -2,6 +2,9 @@ package kingpinMock
 
 import (
 	"fmt"
+	"net/url"
+	"time"
+
 	"github.com/kr/text"
 	"github.com/forj-oss/forjj-modules/cli/clier"
 	"github.com/forj-oss/forjj-modules/trace"
@@ -17,6 +20,7 @@ type ArgClause struct {
 	set_value ClauseList
 	context   string // Context value
 	value     interface{}
+	enum      []string // Enum allowed values.
 }
 
 func (a *ArgClause) Stringer() string {
@@ -32,6 +36,8 @@ func (a *ArgClause) Stringer() string {
 		ret += fmt.Sprintf("  value: '%s' (string - %p)\n", *a.value.(*string), a.value)
 	case *bool:
 		ret += fmt.Sprintf("  value: '%t' (bool - %p)\n", *a.value.(*bool), a.value)
+	case *int, *float64, *time.Duration, **url.URL:
+		ret += fmt.Sprintf("  value: '%v' (%s - %p)\n", a.value, typeName(a.vtype), a.value)
 	}
 	ret += fmt.Sprintf("  context value: '%s'\n", a.context)
 	if a.set_value != nil {
@@ -72,14 +78,91 @@ func (a *ArgClause) String() (ret *string) {
 	return
 }
 
-func (a *ArgClause) GetType() string {
-	switch {
-	case a.vtype == BoolType:
-		return "bool"
-	case a.vtype == StringType:
-		return "string"
+func (a *ArgClause) Int() (ret *int) {
+	if a.vtype != NilType && a.vtype != IntType {
+		return nil
+	}
+	if a.vtype == NilType {
+		a.vtype = IntType
+		ret = new(int)
+		a.value = ret
+	} else {
+		ret = a.value.(*int)
+	}
+	return
+}
+
+func (a *ArgClause) Float64() (ret *float64) {
+	if a.vtype != NilType && a.vtype != FloatType {
+		return nil
+	}
+	if a.vtype == NilType {
+		a.vtype = FloatType
+		ret = new(float64)
+		a.value = ret
+	} else {
+		ret = a.value.(*float64)
+	}
+	return
+}
+
+func (a *ArgClause) Duration() (ret *time.Duration) {
+	if a.vtype != NilType && a.vtype != DurationType {
+		return nil
+	}
+	if a.vtype == NilType {
+		a.vtype = DurationType
+		ret = new(time.Duration)
+		a.value = ret
+	} else {
+		ret = a.value.(*time.Duration)
+	}
+	return
+}
+
+func (a *ArgClause) URL() (ret **url.URL) {
+	if a.vtype != NilType && a.vtype != URLType {
+		return nil
+	}
+	if a.vtype == NilType {
+		a.vtype = URLType
+		ret = new(*url.URL)
+		a.value = ret
+	} else {
+		ret = a.value.(**url.URL)
+	}
+	return
+}
+
+func (a *ArgClause) Enum(options ...string) (ret *string) {
+	if a.vtype != NilType && a.vtype != EnumType {
+		return nil
+	}
+	if a.vtype == NilType {
+		a.vtype = EnumType
+		a.enum = options
+		ret = new(string)
+		a.value = ret
+	} else {
+		ret = a.value.(*string)
+	}
+	return
+}
+
+func (a *ArgClause) IsEnum(options ...string) bool {
+	if len(options) != len(a.enum) {
+		return false
+	}
+	for i, option := range options {
+		if a.enum[i] != option {
+			return false
+		}
 	}
-	return "any"
+	return true
+}
+
+func (a *ArgClause) GetType() string {
+	return typeName(a.vtype)
 }
 
 func (a *ArgClause) Bool() (ret *bool) {
@@ -177,5 +260,7 @@ func (a *ArgClause) update_data() {
 		if a.context == "true" {
 			*b = true
 		}
+	default:
+		setTypedValue(a.value, a.context)
 	}
 }
@@ -57,6 +57,40 @@ func TestArgClause_String(t *testing.T) {
 
 }
 
+func TestArgClause_Duration(t *testing.T) {
+	t.Log("Setting duration type")
+	a := NewArg("test", "help")
+
+	b := a.Duration()
+
+	bt := reflect.TypeOf(b).String()
+	if bt != "*time.Duration" {
+		t.Errorf("Expected returned value type to be *time.Duration. Got: %s", bt)
+	}
+
+	if a.GetType() != "duration" {
+		t.Errorf("Expected arg to be set as duration. Got : %s", a.GetType())
+	}
+}
+
+func TestArgClause_URL(t *testing.T) {
+	t.Log("Setting url type")
+	a := NewArg("test", "help")
+
+	b := a.URL()
+
+	bt := reflect.TypeOf(b).String()
+	if bt != "**url.URL" {
+		t.Errorf("Expected returned value type to be **url.URL. Got: %s", bt)
+	}
+
+	a.SetContextValue("https://github.com/forj-oss")
+	a.update_data()
+	if *b == nil || (*b).Host != "github.com" {
+		t.Errorf("Expected arg value to be updated to an URL. Got %s", *b)
+	}
+}
+
 func TestArgClause_Default(t *testing.T) {
 	value := "default"
 	function := "Default"
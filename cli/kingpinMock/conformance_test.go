@@ -0,0 +1,29 @@
+package kingpinMock
+
+import (
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/cli/clier/cliertest"
+)
+
+// mockArgs is the cliertest.ConformanceBackend.Args of the mock. Commands are given as 'cmd:<name>' followed by flags and
+// arguments name/value pairs.
+func mockArgs(cmds []string, params ...cliertest.ConformanceParam) []string {
+	args := make([]string, 0, len(cmds)+2*len(params))
+	for _, cmd := range cmds {
+		args = append(args, "cmd:"+cmd)
+	}
+	for _, param := range params {
+		args = append(args, param.Name, param.Value)
+	}
+	return args
+}
+
+func TestConformance(t *testing.T) {
+	t.Log("Expect kingpinMock to pass the clier conformance suite.")
+	cliertest.RunConformance(t, cliertest.ConformanceBackend{
+		New:  func() clier.Applicationer { return New("test") },
+		Args: mockArgs,
+	})
+}
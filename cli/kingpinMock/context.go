@@ -2,6 +2,7 @@ package kingpinMock
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/forj-oss/forjj-modules/cli/clier"
@@ -51,46 +52,82 @@ func (p *ParseContext) IsInvalidContext() bool {
 	return p == nil
 }
 
-// IMPORTANT! The Mock GetFlagValue && GetArgValue are not configured to get from process environment
-
+// GetFlagValue get value from context, or if missing, ENV or if missing, defaults. (See cliertest.RunConformance)
+//
+// Repeatable flags values are returned as []string.
 func (p *ParseContext) GetFlagValue(f clier.FlagClauser) (interface{}, bool) {
+	v, source := p.getFlagValue(f)
+	return v, source != ""
+}
+
+// GetFlagSource return the source of the flag value. (See clier.ParseContexter)
+func (p *ParseContext) GetFlagSource(f clier.FlagClauser) string {
+	_, source := p.getFlagValue(f)
+	return source
+}
+
+func (p *ParseContext) getFlagValue(f clier.FlagClauser) (interface{}, string) {
 	var flag *FlagClause
 
 	if v, ok := f.(*FlagClause); !ok {
-		return nil, false
+		return nil, ""
 	} else {
 		flag = v
 	}
 
 	for _, element := range p.app.context.Elements {
 		if f, ok := element.(*FlagClause); ok && f == flag {
-			return f.context, true
+			if f.IsMulti() {
+				return append([]string{}, f.contexts...), clier.SourceCli
+			}
+			return f.context, clier.SourceCli
+		}
+	}
+	if v := os.Getenv(flag.envar); v != "" {
+		if flag.IsMulti() {
+			return clier.EnvarValues(v), clier.SourceEnvar
 		}
+		return v, clier.SourceEnvar
 	}
 	if flag.hasDefaults() {
-		return flag.getDefaults(), true
+		return flag.getDefaults(), clier.SourceDefault
 	}
-	return nil, false
+	return nil, ""
 }
 
+// GetArgValue get value from context, or if missing, ENV or if missing, defaults. (See cliertest.RunConformance)
 func (p *ParseContext) GetArgValue(a clier.ArgClauser) (interface{}, bool) {
+	v, source := p.getArgValue(a)
+	return v, source != ""
+}
+
+// GetArgSource return the source of the arg value. (See clier.ParseContexter)
+func (p *ParseContext) GetArgSource(a clier.ArgClauser) string {
+	_, source := p.getArgValue(a)
+	return source
+}
+
+func (p *ParseContext) getArgValue(a clier.ArgClauser) (interface{}, string) {
 	var arg *ArgClause
 
 	if v, ok := a.(*ArgClause); !ok {
-		return nil, false
+		return nil, ""
 	} else {
 		arg = v
 	}
 
 	for _, element := range p.app.context.Elements {
-		if v, ok := element.(*ArgClause); ok && a == arg {
-			return v.context, true
+		if v, ok := element.(*ArgClause); ok && v == arg {
+			return v.context, clier.SourceCli
 		}
 	}
+	if v := os.Getenv(arg.envar); v != "" {
+		return v, clier.SourceEnvar
+	}
 	if arg.hasDefaults() {
-		return arg.getDefaults(), true
+		return arg.getDefaults(), clier.SourceDefault
 	}
-	return nil, false
+	return nil, ""
 }
 
 func (p *ParseContext) GetParam(name string) (interface{}, string) {
@@ -133,6 +170,12 @@ func (a *Application) NewContext() *ParseContext {
 }
 
 func (p *ParseContext) SetContext(p1 ...string) *ParseContext {
+	// Repeatable flags values are collected from the new context only.
+	for _, element := range p.Elements {
+		if f, ok := element.(*FlagClause); ok {
+			f.contexts = nil
+		}
+	}
 	p.cmds = make([]*CmdClause, 0, len(p1))
 	p.Elements = make([]interface{}, 0, len(p1))
 	if len(p1) == 0 {
@@ -200,6 +243,8 @@ func (p *ParseContext) setValue(context, cli bool, name string, value string) (*
 					} else {
 						*v.value.(*bool) = false
 					}
+				default:
+					setTypedValue(v.value, value)
 				}
 			}
 			p.Elements = append(p.Elements, v)
@@ -223,6 +268,8 @@ func (p *ParseContext) setValue(context, cli bool, name string, value string) (*
 					} else {
 						*v.value.(*bool) = false
 					}
+				default:
+					setTypedValue(v.value, value)
 				}
 			}
 			p.Elements = append(p.Elements, v)
@@ -251,6 +298,8 @@ func (p *ParseContext) setValue(context, cli bool, name string, value string) (*
 				} else {
 					*v.value.(*bool) = false
 				}
+			default:
+				setTypedValue(v.value, value)
 			}
 		}
 		p.Elements = append(p.Elements, v)
@@ -274,6 +323,8 @@ func (p *ParseContext) setValue(context, cli bool, name string, value string) (*
 				} else {
 					*v.value.(*bool) = false
 				}
+			default:
+				setTypedValue(v.value, value)
 			}
 		}
 		p.Elements = append(p.Elements, v)
@@ -305,6 +356,8 @@ func (p *ParseContext) SetParsedAppValue(name string, value string) *ParseContex
 			} else {
 				*v.value.(*bool) = false
 			}
+		default:
+			setTypedValue(v.value, value)
 		}
 	}
 	return p
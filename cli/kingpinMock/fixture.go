@@ -0,0 +1,376 @@
+package kingpinMock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// Fixture is the list of declarations and parse results recorded by a Recorder.
+//
+// It is saved as json and can rebuild an equivalent mock Application. (See NewApplication and ReplayFixture)
+type Fixture struct {
+	Declarations []*FixtureDeclaration `json:"declarations"`
+	Parses       []*FixtureParse       `json:"parses"`
+}
+
+// Fixture declarations kinds.
+const (
+	FixtureCommand = "command"
+	FixtureFlag    = "flag"
+	FixtureArg     = "arg"
+)
+
+// FixtureValueType is the type of flags/args declared with SetValue.
+const FixtureValueType = "value"
+
+// Fixture values sources, as reported by the backend. (See clier.ParseContexter)
+const (
+	FixtureSourceCli     = clier.SourceCli
+	FixtureSourceEnvar   = clier.SourceEnvar
+	FixtureSourceDefault = clier.SourceDefault
+)
+
+// FixtureDeclaration is a command, flag or argument declaration. Path is the list of parent commands names.
+type FixtureDeclaration struct {
+	Kind     string   `json:"kind"`
+	Path     []string `json:"path,omitempty"`
+	Name     string   `json:"name"`
+	Help     string   `json:"help,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+	Required bool     `json:"required,omitempty"`
+	Hidden   bool     `json:"hidden,omitempty"`
+	Short    string   `json:"short,omitempty"`
+	Default  *string  `json:"default,omitempty"`
+	Envar    string   `json:"envar,omitempty"`
+}
+
+// FixtureParse is a ParseContext (Context is true) or a Parse result.
+type FixtureParse struct {
+	Args     []string          `json:"args"`
+	Context  bool              `json:"context,omitempty"`
+	Commands []string          `json:"commands,omitempty"`
+	Values   []*FixtureValue   `json:"values,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	Command  string            `json:"command,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// FixtureValue is a flag or argument context value. Repeatable flags values are stored in Values.
+type FixtureValue struct {
+	Kind   string   `json:"kind"`
+	Path   []string `json:"path,omitempty"`
+	Name   string   `json:"name"`
+	Source string   `json:"source"`
+	Value  string   `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// Save writes the fixture to file as json.
+func (f *Fixture) Save(file string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Unable to encode the fixture. %s", err)
+	}
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("Unable to save the fixture. %s", err)
+	}
+	return nil
+}
+
+// LoadFixture reads a fixture saved by Recorder.Save.
+func LoadFixture(file string) (*Fixture, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read the fixture. %s", err)
+	}
+	f := new(Fixture)
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("Unable to decode fixture '%s'. %s", file, err)
+	}
+	return f, nil
+}
+
+// NewApplication creates a mock Application with the fixture declarations.
+func (f *Fixture) NewApplication() *Application {
+	app := New("")
+	for _, decl := range f.Declarations {
+		var cmd *CmdClause
+		if len(decl.Path) > 0 {
+			if cmd = app.GetCommand(decl.Path...); cmd == nil {
+				continue
+			}
+		}
+		switch decl.Kind {
+		case FixtureCommand:
+			if cmd == nil {
+				app.Command(decl.Name, decl.Help)
+			} else {
+				cmd.Command(decl.Name, decl.Help)
+			}
+		case FixtureFlag:
+			var flag *FlagClause
+			if cmd == nil {
+				flag = app.Flag(decl.Name, decl.Help).(*FlagClause)
+			} else {
+				flag = cmd.Flag(decl.Name, decl.Help).(*FlagClause)
+			}
+			decl.declareFlag(flag)
+		case FixtureArg:
+			var arg *ArgClause
+			if cmd == nil {
+				arg = app.Arg(decl.Name, decl.Help).(*ArgClause)
+			} else {
+				arg = cmd.Arg(decl.Name, decl.Help).(*ArgClause)
+			}
+			decl.declareArg(arg)
+		}
+	}
+	return app
+}
+
+func (d *FixtureDeclaration) declareFlag(flag *FlagClause) {
+	switch d.Type {
+	case "":
+	case typeName(BoolType):
+		flag.Bool()
+	case typeName(IntType):
+		flag.Int()
+	case typeName(FloatType):
+		flag.Float64()
+	case typeName(DurationType):
+		flag.Duration()
+	case typeName(EnumType):
+		flag.Enum(d.Enum...)
+	case typeName(URLType):
+		flag.URL()
+	case typeName(StringsType):
+		flag.Strings()
+	case typeName(MapType):
+		flag.StringMap()
+	default:
+		flag.String()
+	}
+	if d.Required {
+		flag.Required()
+	}
+	if d.Hidden {
+		flag.Hidden()
+	}
+	if d.Short != "" {
+		flag.Short([]rune(d.Short)[0])
+	}
+	if d.Default != nil {
+		flag.Default(*d.Default)
+	}
+	if d.Envar != "" {
+		flag.Envar(d.Envar)
+	}
+}
+
+func (d *FixtureDeclaration) declareArg(arg *ArgClause) {
+	switch d.Type {
+	case "":
+	case typeName(BoolType):
+		arg.Bool()
+	case typeName(IntType):
+		arg.Int()
+	case typeName(FloatType):
+		arg.Float64()
+	case typeName(DurationType):
+		arg.Duration()
+	case typeName(EnumType):
+		arg.Enum(d.Enum...)
+	case typeName(URLType):
+		arg.URL()
+	default:
+		arg.String()
+	}
+	if d.Required {
+		arg.Required()
+	}
+	if d.Default != nil {
+		arg.Default(*d.Default)
+	}
+	if d.Envar != "" {
+		arg.Envar(d.Envar)
+	}
+}
+
+// MockArgs returns the mock Application args of the parse: the selected commands followed by the cli values of the
+// last selected command flags and arguments, or of the application if no command is selected.
+//
+// The mock cannot set other values from args. (See Apply)
+func (p *FixtureParse) MockArgs() []string {
+	args := make([]string, 0, len(p.Commands)+2*len(p.Values))
+	for _, cmd := range p.Commands {
+		args = append(args, "cmd:"+cmd)
+	}
+	for _, v := range p.Values {
+		if v.Source != FixtureSourceCli || !isSamePath(v.Path, p.Commands) {
+			continue
+		}
+		if v.Values == nil {
+			args = append(args, v.Name, v.Value)
+			continue
+		}
+		for _, value := range v.Values {
+			args = append(args, v.Name, value)
+		}
+	}
+	return args
+}
+
+// Apply sets in the app context the cli values not given by MockArgs, ie the application or parent commands flags.
+func (p *FixtureParse) Apply(app *Application) {
+	if app.context == nil {
+		app.NewContext()
+	}
+	for _, v := range p.Values {
+		if v.Kind != FixtureFlag || v.Source != FixtureSourceCli || isSamePath(v.Path, p.Commands) {
+			continue
+		}
+		flag := app.GetFlag(append(append([]string{}, v.Path...), v.Name)...)
+		if flag == nil {
+			continue
+		}
+		values := v.Values
+		if values == nil {
+			values = []string{v.Value}
+		}
+		for _, value := range values {
+			flag.SetContextValue(value)
+		}
+		app.context.Elements = append(app.context.Elements, flag)
+	}
+}
+
+// SetEnv sets the environment variables of the parse, and unset other fixture declared ones.
+// The returned function restores the environment.
+func (f *Fixture) SetEnv(p *FixtureParse) (restore func()) {
+	saved := make(map[string]*string)
+	for _, decl := range f.Declarations {
+		if decl.Envar == "" {
+			continue
+		}
+		if _, found := saved[decl.Envar]; found {
+			continue
+		}
+		if v, found := os.LookupEnv(decl.Envar); found {
+			saved[decl.Envar] = &v
+		} else {
+			saved[decl.Envar] = nil
+		}
+		if v, found := p.Env[decl.Envar]; found {
+			os.Setenv(decl.Envar, v)
+		} else {
+			os.Unsetenv(decl.Envar)
+		}
+	}
+	return func() {
+		for name, v := range saved {
+			if v == nil {
+				os.Unsetenv(name)
+			} else {
+				os.Setenv(name, *v)
+			}
+		}
+	}
+}
+
+// ReplayFixture rebuilds the mock Application of a fixture file and checks that each recorded parse gives the same
+// commands and values on the mock. Failing parses are not replayed.
+func ReplayFixture(t *testing.T, file string) {
+	f, err := LoadFixture(file)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for i, p := range f.Parses {
+		if p.Error != "" {
+			t.Logf("%s: parse %d '%s' skipped. It failed with: %s", file, i, strings.Join(p.Args, " "), p.Error)
+			continue
+		}
+		restore := f.SetEnv(p)
+		f.replay(t, fmt.Sprintf("%s: parse %d '%s'", file, i, strings.Join(p.Args, " ")), p)
+		restore()
+	}
+}
+
+// replay runs one recorded parse on a new mock Application.
+func (f *Fixture) replay(t *testing.T, name string, p *FixtureParse) {
+	app := f.NewApplication()
+
+	context, err := app.ParseContext(p.MockArgs())
+	if err != nil || context == nil {
+		t.Errorf("%s: Expected ParseContext() to work. Got %s", name, err)
+		return
+	}
+	p.Apply(app)
+
+	cmds := make([]string, 0, len(p.Commands))
+	for _, cmd := range context.SelectedCommands() {
+		cmds = append(cmds, cmd.(*CmdClause).command)
+	}
+	if !isSamePath(cmds, p.Commands) {
+		t.Errorf("%s: Expected commands to be '%s'. Got '%s'", name, strings.Join(p.Commands, " "),
+			strings.Join(cmds, " "))
+	}
+	if !p.Context && p.Command != "" && p.Command != strings.Join(cmds, " ") {
+		t.Errorf("%s: Expected Parse() to return '%s'. Got '%s'", name, p.Command, strings.Join(cmds, " "))
+	}
+
+	for _, v := range p.Values {
+		path := append(append([]string{}, v.Path...), v.Name)
+		var value interface{}
+		var found bool
+		if v.Kind == FixtureFlag {
+			if flag := app.GetFlag(path...); flag != nil {
+				value, found = context.GetFlagValue(flag)
+			}
+		} else if arg := app.GetArg(path...); arg != nil {
+			value, found = context.GetArgValue(arg)
+		}
+		expected := v.Value
+		if v.Values != nil {
+			expected = strings.Join(v.Values, ",")
+		}
+		if got := fixtureValue(value); !found || got != expected {
+			t.Errorf("%s: Expected %s '%s' value to be '%s'. Got '%s' (%t)", name, v.Kind, strings.Join(path, " "),
+				expected, got, found)
+		}
+	}
+}
+
+// fixtureValue returns a context value as a string. Repeatable values are comma separated.
+func fixtureValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case *string:
+		if value != nil {
+			return *value
+		}
+	case []string:
+		return strings.Join(value, ",")
+	}
+	return ""
+}
+
+func isSamePath(p1, p2 []string) bool {
+	if len(p1) != len(p2) {
+		return false
+	}
+	for i := range p1 {
+		if p1[i] != p2[i] {
+			return false
+		}
+	}
+	return true
+}
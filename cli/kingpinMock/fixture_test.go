@@ -0,0 +1,140 @@
+package kingpinMock
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/alecthomas/kingpin"
+	"github.com/forj-oss/forjj-modules/cli/kingpinCli"
+)
+
+const fixtureTestEnvar = "KINGPINMOCK_FIXTURE_TOKEN"
+
+// recordFixture records 'create repo' command line parses of a real kingpin application.
+func recordFixture(t *testing.T) *Recorder {
+	r := NewRecorder(kingpinCli.New(kingpin.New("test", ""), "test"))
+	r.Flag("debug", "debug help").Bool()
+	create := r.Command("create", "create help")
+	create.Flag("owner", "owner help").Default("me").String()
+	repo := create.Command("repo", "repo help")
+	repo.Flag("title", "title help").Short('t').String()
+	repo.Flag("token", "token help").Envar(fixtureTestEnvar).String()
+	repo.Flag("label", "label help").Strings()
+	repo.Arg("name", "name help").Required().String()
+
+	os.Setenv(fixtureTestEnvar, "secret")
+	defer os.Unsetenv(fixtureTestEnvar)
+	if _, err := r.ParseContext([]string{"create", "repo", "-t", "my title", "myrepo"}); err != nil {
+		t.Errorf("Expected ParseContext() to work. Got %s", err)
+	}
+	if _, err := r.Parse([]string{"--debug", "create", "repo", "--label", "a", "--label", "b", "myrepo"}); err != nil {
+		t.Errorf("Expected Parse() to work. Got %s", err)
+	}
+	return r
+}
+
+func TestRecorder(t *testing.T) {
+	t.Log("Expect the Recorder to record declarations and parses of the real application.")
+
+	// --- Run the test ---
+	f := recordFixture(t).Fixture()
+
+	// --- Start testing ---
+	if len(f.Declarations) != 8 {
+		t.Errorf("Expected 8 declarations. Got %d", len(f.Declarations))
+	}
+	if len(f.Parses) != 2 {
+		t.Errorf("Expected 2 parses. Got %d", len(f.Parses))
+		return
+	}
+	p := f.Parses[0]
+	if !p.Context || !isSamePath(p.Commands, []string{"create", "repo"}) {
+		t.Errorf("Expected a context parse of 'create repo'. Got %t, '%s'", p.Context, p.Commands)
+	}
+	sources := make(map[string]string)
+	for _, v := range p.Values {
+		sources[v.Name] = v.Source + ":" + v.Value
+	}
+	expected := map[string]string{
+		"owner": FixtureSourceDefault + ":me",
+		"title": FixtureSourceCli + ":my title",
+		"token": FixtureSourceEnvar + ":secret",
+		"name":  FixtureSourceCli + ":myrepo",
+	}
+	for name, value := range expected {
+		if sources[name] != value {
+			t.Errorf("Expected '%s' to be '%s'. Got '%s'", name, value, sources[name])
+		}
+	}
+	if p.Env[fixtureTestEnvar] != "secret" {
+		t.Errorf("Expected '%s' to be recorded. Got '%s'", fixtureTestEnvar, p.Env[fixtureTestEnvar])
+	}
+	if p = f.Parses[1]; p.Context || p.Command != "create repo" {
+		t.Errorf("Expected a Parse of 'create repo'. Got %t, '%s'", p.Context, p.Command)
+	}
+}
+
+func TestFixture_NewApplication(t *testing.T) {
+	t.Log("Expect the fixture to rebuild an equivalent mock Application.")
+
+	// --- Setting test context ---
+	f := recordFixture(t).Fixture()
+
+	// --- Run the test ---
+	app := f.NewApplication()
+
+	// --- Start testing ---
+	if app.GetCommand("create", "repo") == nil {
+		t.Error("Expected command 'create repo' to exist.")
+	}
+	if flag := app.GetFlag("create", "owner"); flag == nil || !flag.IsDefault("me") {
+		t.Error("Expected flag 'create owner' to exist with default 'me'.")
+	}
+	if flag := app.GetFlag("create", "repo", "title"); flag == nil || !flag.IsShort('t') {
+		t.Error("Expected flag 'create repo title' to exist with short 't'.")
+	}
+	if flag := app.GetFlag("create", "repo", "label"); flag == nil || !flag.IsMulti() {
+		t.Error("Expected flag 'create repo label' to be repeatable.")
+	}
+	if arg := app.GetArg("create", "repo", "name"); arg == nil || !arg.IsRequired() {
+		t.Error("Expected arg 'create repo name' to be required.")
+	}
+	if flag := app.GetFlag("debug"); flag == nil || flag.GetType() != typeName(BoolType) {
+		t.Error("Expected app flag 'debug' to be a bool.")
+	}
+}
+
+func TestReplayFixture(t *testing.T) {
+	t.Log("Expect a saved fixture to be replayed on the mock.")
+
+	// --- Setting test context ---
+	dir, err := ioutil.TempDir("", "kingpinMock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "create-repo.json")
+
+	// --- Run the test ---
+	err = recordFixture(t).Save(file)
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Save() to work. Got %s", err)
+		return
+	}
+	ReplayFixture(t, file)
+	if _, found := os.LookupEnv(fixtureTestEnvar); found {
+		t.Errorf("Expected '%s' to be restored.", fixtureTestEnvar)
+	}
+
+	// --- Run the test ---
+	_, err = LoadFixture(path.Join(dir, "missing.json"))
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected LoadFixture() to fail on a missing file.")
+	}
+}
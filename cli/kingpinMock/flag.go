@@ -2,6 +2,9 @@ package kingpinMock
 
 import (
 	"fmt"
+	"net/url"
+	"time"
+
 	"github.com/kr/text"
 	"github.com/forj-oss/forjj-modules/cli/clier"
 	"github.com/forj-oss/forjj-modules/trace"
@@ -18,6 +21,8 @@ type FlagClause struct {
 	envar     string
 	context   string // Context value
 	value     interface{}
+	enum      []string // Enum allowed values.
+	contexts  []string // Repeatable flag context values.
 	set_value ClauseList
 }
 
@@ -36,8 +41,13 @@ func (a *FlagClause) Stringer() string {
 		ret += fmt.Sprintf("  value: '%s' (string - %p)\n", *a.value.(*string), a.value)
 	case *bool:
 		ret += fmt.Sprintf("  value: '%t' (bool - %p)\n", *a.value.(*bool), a.value)
+	case *int, *float64, *time.Duration, **url.URL, *[]string, *map[string]string:
+		ret += fmt.Sprintf("  value: '%v' (%s - %p)\n", a.value, typeName(a.vtype), a.value)
 	}
 	ret += fmt.Sprintf("  context value: '%s'\n", a.context)
+	if a.IsMulti() {
+		ret += fmt.Sprintf("  context values: '%s'\n", a.contexts)
+	}
 	if a.set_value != nil {
 		ret += "  set_value:\n"
 		ret += text.Indent(a.set_value.String(), "    ")
@@ -90,14 +100,124 @@ func (f *FlagClause) Bool() (ret *bool) {
 	return
 }
 
-func (a *FlagClause) GetType() string {
-	switch {
-	case a.vtype == StringType:
-		return "string"
-	case a.vtype == BoolType:
-		return "bool"
+func (f *FlagClause) Int() (ret *int) {
+	if f.vtype != NilType && f.vtype != IntType {
+		return nil
+	}
+	if f.vtype == NilType {
+		f.vtype = IntType
+		ret = new(int)
+		f.value = ret
+	} else {
+		ret = f.value.(*int)
+	}
+	return
+}
+
+func (f *FlagClause) Float64() (ret *float64) {
+	if f.vtype != NilType && f.vtype != FloatType {
+		return nil
+	}
+	if f.vtype == NilType {
+		f.vtype = FloatType
+		ret = new(float64)
+		f.value = ret
+	} else {
+		ret = f.value.(*float64)
+	}
+	return
+}
+
+func (f *FlagClause) Duration() (ret *time.Duration) {
+	if f.vtype != NilType && f.vtype != DurationType {
+		return nil
+	}
+	if f.vtype == NilType {
+		f.vtype = DurationType
+		ret = new(time.Duration)
+		f.value = ret
+	} else {
+		ret = f.value.(*time.Duration)
+	}
+	return
+}
+
+func (f *FlagClause) URL() (ret **url.URL) {
+	if f.vtype != NilType && f.vtype != URLType {
+		return nil
+	}
+	if f.vtype == NilType {
+		f.vtype = URLType
+		ret = new(*url.URL)
+		f.value = ret
+	} else {
+		ret = f.value.(**url.URL)
+	}
+	return
+}
+
+func (f *FlagClause) Enum(options ...string) (ret *string) {
+	if f.vtype != NilType && f.vtype != EnumType {
+		return nil
 	}
-	return "any"
+	if f.vtype == NilType {
+		f.vtype = EnumType
+		f.enum = options
+		ret = new(string)
+		f.value = ret
+	} else {
+		ret = f.value.(*string)
+	}
+	return
+}
+
+func (f *FlagClause) Strings() (ret *[]string) {
+	if f.vtype != NilType && f.vtype != StringsType {
+		return nil
+	}
+	if f.vtype == NilType {
+		f.vtype = StringsType
+		ret = new([]string)
+		f.value = ret
+	} else {
+		ret = f.value.(*[]string)
+	}
+	return
+}
+
+func (f *FlagClause) StringMap() (ret *map[string]string) {
+	if f.vtype != NilType && f.vtype != MapType {
+		return nil
+	}
+	if f.vtype == NilType {
+		f.vtype = MapType
+		ret = new(map[string]string)
+		f.value = ret
+	} else {
+		ret = f.value.(*map[string]string)
+	}
+	return
+}
+
+// IsMulti return true if the flag is repeatable. (Strings, StringMap)
+func (f *FlagClause) IsMulti() bool {
+	return (f.vtype == StringsType || f.vtype == MapType)
+}
+
+func (f *FlagClause) IsEnum(options ...string) bool {
+	if len(options) != len(f.enum) {
+		return false
+	}
+	for i, option := range options {
+		if f.enum[i] != option {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *FlagClause) GetType() string {
+	return typeName(a.vtype)
 }
 
 func (f *FlagClause) Required() clier.FlagClauser {
@@ -172,9 +292,17 @@ func (f *FlagClause) IsSetValue(_ clier.Valuer) (ret bool) {
 
 func (f *FlagClause) SetContextValue(s string) (*FlagClause, error) {
 	f.context = s
+	if f.IsMulti() {
+		f.contexts = append(f.contexts, s)
+	}
 	return f, nil
 }
 
+// GetContextValues return all context values of a repeatable flag.
+func (f *FlagClause) GetContextValues() []string {
+	return f.contexts
+}
+
 func (f *FlagClause) GetContextValue() string {
 	return f.context
 }
@@ -189,5 +317,17 @@ func (f *FlagClause) update_data() {
 		if f.context == "true" {
 			*b = true
 		}
+	case *[]string:
+		*f.value.(*[]string) = nil
+		for _, context := range f.contexts {
+			setTypedValue(f.value, context)
+		}
+	case *map[string]string:
+		*f.value.(*map[string]string) = nil
+		for _, context := range f.contexts {
+			setTypedValue(f.value, context)
+		}
+	default:
+		setTypedValue(f.value, f.context)
 	}
 }
@@ -57,6 +57,78 @@ func TestFlagClause_String(t *testing.T) {
 
 }
 
+func TestFlagClause_Int(t *testing.T) {
+	t.Log("Setting int type")
+	a := NewFlag("test", "help")
+
+	b := a.Int()
+
+	bt := reflect.TypeOf(b).String()
+	if bt != "*int" {
+		t.Errorf("Expected returned value type to be *int. Got: %s", bt)
+	}
+
+	if a.GetType() != "int" {
+		t.Errorf("Expected flag to be set as int. Got : %s", a.GetType())
+	}
+
+	if a.String() != nil {
+		t.Error("Expected String() to return nil on an int flag.")
+	}
+
+	a.SetContextValue("12")
+	a.update_data()
+	if *b != 12 {
+		t.Errorf("Expected flag value to be updated to 12. Got %d", *b)
+	}
+}
+
+func TestFlagClause_Enum(t *testing.T) {
+	t.Log("Setting enum type")
+	a := NewFlag("test", "help")
+
+	b := a.Enum("json", "yaml")
+
+	bt := reflect.TypeOf(b).String()
+	if bt != "*string" {
+		t.Errorf("Expected returned value type to be *string. Got: %s", bt)
+	}
+
+	if a.GetType() != "enum" {
+		t.Errorf("Expected flag to be set as enum. Got : %s", a.GetType())
+	}
+
+	if !a.IsEnum("json", "yaml") {
+		t.Errorf("Expected enum values to be 'json, yaml'. Got %s", a.enum)
+	}
+}
+
+func TestFlagClause_Strings(t *testing.T) {
+	t.Log("Setting repeatable string slice type")
+	a := NewFlag("test", "help")
+
+	b := a.Strings()
+
+	bt := reflect.TypeOf(b).String()
+	if bt != "*[]string" {
+		t.Errorf("Expected returned value type to be *[]string. Got: %s", bt)
+	}
+
+	if !a.IsMulti() {
+		t.Error("Expected flag to be repeatable.")
+	}
+
+	a.SetContextValue("a")
+	a.SetContextValue("b")
+	a.update_data()
+	if !reflect.DeepEqual(*b, []string{"a", "b"}) {
+		t.Errorf("Expected flag value to be updated to [a b]. Got %s", *b)
+	}
+	if v := a.GetContextValues(); len(v) != 2 {
+		t.Errorf("Expected 2 context values. Got %d", len(v))
+	}
+}
+
 func TestFlagClause_Default(t *testing.T) {
 	value := "default"
 	function := "Default"
@@ -1,7 +1,78 @@
 package kingpinMock
 
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
 const (
-	NilType    = 0
-	StringType = 1
-	BoolType   = 2
+	NilType      = 0
+	StringType   = 1
+	BoolType     = 2
+	IntType      = 3
+	FloatType    = 4
+	DurationType = 5
+	EnumType     = 6
+	URLType      = 7
+	StringsType  = 8
+	MapType      = 9
 )
+
+// typeName return the value type name as used by forjj-modules/cli.
+func typeName(vtype int) string {
+	switch vtype {
+	case StringType:
+		return "string"
+	case BoolType:
+		return "bool"
+	case IntType:
+		return "int"
+	case FloatType:
+		return "float"
+	case DurationType:
+		return "duration"
+	case EnumType:
+		return "enum"
+	case URLType:
+		return "url"
+	case StringsType:
+		return "string-slice"
+	case MapType:
+		return "string-map"
+	}
+	return "any"
+}
+
+// setTypedValue set an int, float64, duration or url value from a string.
+// The value is not updated if the string cannot be parsed.
+func setTypedValue(value interface{}, s string) {
+	switch v := value.(type) {
+	case *int:
+		if i, err := strconv.Atoi(s); err == nil {
+			*v = i
+		}
+	case *float64:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			*v = f
+		}
+	case *time.Duration:
+		if d, err := time.ParseDuration(s); err == nil {
+			*v = d
+		}
+	case **url.URL:
+		if u, err := url.Parse(s); err == nil {
+			*v = u
+		}
+	case *[]string:
+		*v = append(*v, s)
+	case *map[string]string:
+		if *v == nil {
+			*v = make(map[string]string)
+		}
+		if kv := strings.SplitN(s, "=", 2); len(kv) == 2 {
+			(*v)[kv[0]] = kv[1]
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package kingpinMock
+
+import (
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// Recorder is a clier.Applicationer running a real backend and recording every declaration and parse result in a
+// Fixture. See LoadFixture to rebuild an equivalent mock Application.
+//
+// Ex:
+//
+//	r := kingpinMock.NewRecorder(kingpinCli.New(kingpin.New("forjj", ""), "forjj"))
+//	c := cli.NewForjCli(r)
+//	...
+//	c.Parse(os.Args[1:], nil)
+//	r.Save("create-repo.json")
+type Recorder struct {
+	app     clier.Applicationer
+	root    *RecordCmd
+	fixture Fixture
+}
+
+// NewRecorder creates a Recorder of app.
+func NewRecorder(app clier.Applicationer) *Recorder {
+	r := &Recorder{app: app}
+	r.root = &RecordCmd{rec: r, path: []string{}}
+	return r
+}
+
+// Fixture return the recorded fixture.
+func (r *Recorder) Fixture() *Fixture {
+	if r == nil {
+		return nil
+	}
+	return &r.fixture
+}
+
+// Save writes the recorded fixture to file.
+func (r *Recorder) Save(file string) error {
+	return r.fixture.Save(file)
+}
+
+func (r *Recorder) IsNil() bool {
+	if r == nil {
+		return true
+	}
+	return r.app.IsNil()
+}
+
+func (r *Recorder) Flag(p1, p2 string) clier.FlagClauser {
+	return r.root.newFlag(r.app.Flag(p1, p2), p1, p2)
+}
+
+func (r *Recorder) Arg(p1, p2 string) clier.ArgClauser {
+	return r.root.newArg(r.app.Arg(p1, p2), p1, p2)
+}
+
+func (r *Recorder) Command(p1, p2 string) clier.CmdClauser {
+	return r.root.newCmd(r.app.Command(p1, p2), p1, p2)
+}
+
+// ParseContext records the commands and flags/args values found by the real backend context.
+func (r *Recorder) ParseContext(args []string) (clier.ParseContexter, error) {
+	context, err := r.app.ParseContext(args)
+	r.record(true, args, context, "", err)
+	if context == nil {
+		return nil, err
+	}
+	return &RecordContext{rec: r, context: context}, err
+}
+
+// Parse records the command selected by the real backend, and the context values of args.
+func (r *Recorder) Parse(args []string) (string, error) {
+	context, _ := r.app.ParseContext(args)
+	cmd, err := r.app.Parse(args)
+	r.record(false, args, context, cmd, err)
+	return cmd, err
+}
+
+func (r *Recorder) Name() string {
+	return r.app.Name()
+}
+
+// record adds a parse result to the fixture.
+func (r *Recorder) record(context_parse bool, args []string, context clier.ParseContexter, cmd string, err error) {
+	p := &FixtureParse{Args: append([]string{}, args...), Context: context_parse, Command: cmd}
+	if err != nil {
+		p.Error = err.Error()
+	}
+	if context != nil && !context.IsInvalidContext() {
+		for _, c := range context.SelectedCommands() {
+			if rc := r.root.find(c); rc != nil {
+				p.Commands = append(p.Commands, rc.name)
+			}
+		}
+		r.root.recordValues(p, context)
+	}
+	r.fixture.Parses = append(r.fixture.Parses, p)
+}
+
+// RecordCmd is a recorded command. The Recorder itself is the root RecordCmd.
+type RecordCmd struct {
+	rec   *Recorder
+	cmd   clier.CmdClauser
+	name  string
+	path  []string
+	cmds  []*RecordCmd
+	flags []*RecordFlag
+	args  []*RecordArg
+}
+
+func (c *RecordCmd) newCmd(cmd clier.CmdClauser, name, help string) *RecordCmd {
+	rc := &RecordCmd{rec: c.rec, cmd: cmd, name: name, path: append(append([]string{}, c.path...), name)}
+	c.cmds = append(c.cmds, rc)
+	c.rec.declare(&FixtureDeclaration{Kind: FixtureCommand, Path: c.path, Name: name, Help: help})
+	return rc
+}
+
+func (c *RecordCmd) newFlag(flag clier.FlagClauser, name, help string) *RecordFlag {
+	rf := &RecordFlag{flag: flag, decl: &FixtureDeclaration{Kind: FixtureFlag, Path: c.path, Name: name, Help: help}}
+	c.flags = append(c.flags, rf)
+	c.rec.declare(rf.decl)
+	return rf
+}
+
+func (c *RecordCmd) newArg(arg clier.ArgClauser, name, help string) *RecordArg {
+	ra := &RecordArg{arg: arg, decl: &FixtureDeclaration{Kind: FixtureArg, Path: c.path, Name: name, Help: help}}
+	c.args = append(c.args, ra)
+	c.rec.declare(ra.decl)
+	return ra
+}
+
+func (r *Recorder) declare(decl *FixtureDeclaration) {
+	r.fixture.Declarations = append(r.fixture.Declarations, decl)
+}
+
+// find return the recorded command of a real backend command. nil if not found.
+func (c *RecordCmd) find(cmd clier.CmdClauser) *RecordCmd {
+	for _, rc := range c.cmds {
+		if rc.cmd.IsEqualTo(cmd) {
+			return rc
+		}
+		if found := rc.find(cmd); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// recordValues adds flags and args values found in the context to the parse result.
+func (c *RecordCmd) recordValues(p *FixtureParse, context clier.ParseContexter) {
+	for _, f := range c.flags {
+		if v, found := context.GetFlagValue(f.flag); found {
+			p.addValue(f.decl, v, context.GetFlagSource(f.flag))
+		}
+	}
+	for _, a := range c.args {
+		if v, found := context.GetArgValue(a.arg); found {
+			p.addValue(a.decl, v, context.GetArgSource(a.arg))
+		}
+	}
+	for _, cmd := range c.cmds {
+		cmd.recordValues(p, context)
+	}
+}
+
+// addValue adds a context value, with the source reported by the backend. Default values are returned by backends
+// as *string.
+func (p *FixtureParse) addValue(decl *FixtureDeclaration, v interface{}, source string) {
+	value := &FixtureValue{Kind: decl.Kind, Path: decl.Path, Name: decl.Name, Source: source}
+	switch v := v.(type) {
+	case *string:
+		if v != nil {
+			value.Value = *v
+		}
+	case string:
+		value.Value = v
+	case []string:
+		value.Values = v
+	}
+	if decl.Envar != "" {
+		if env, found := os.LookupEnv(decl.Envar); found && env != "" {
+			if p.Env == nil {
+				p.Env = make(map[string]string)
+			}
+			p.Env[decl.Envar] = env
+		}
+	}
+	p.Values = append(p.Values, value)
+}
+
+func (c *RecordCmd) Command(p1, p2 string) clier.CmdClauser {
+	return c.newCmd(c.cmd.Command(p1, p2), p1, p2)
+}
+
+func (c *RecordCmd) Flag(p1, p2 string) clier.FlagClauser {
+	return c.newFlag(c.cmd.Flag(p1, p2), p1, p2)
+}
+
+func (c *RecordCmd) Arg(p1, p2 string) clier.ArgClauser {
+	return c.newArg(c.cmd.Arg(p1, p2), p1, p2)
+}
+
+func (c *RecordCmd) FullCommand() string {
+	return c.cmd.FullCommand()
+}
+
+func (c *RecordCmd) IsEqualTo(c_ref clier.CmdClauser) bool {
+	if ref, ok := c_ref.(*RecordCmd); ok {
+		return c.cmd.IsEqualTo(ref.cmd)
+	}
+	return c.cmd.IsEqualTo(c_ref)
+}
+
+// RecordFlag is a recorded flag.
+type RecordFlag struct {
+	flag clier.FlagClauser
+	decl *FixtureDeclaration
+}
+
+func (f *RecordFlag) Stringer() string {
+	return f.flag.Stringer()
+}
+
+func (f *RecordFlag) String() *string {
+	f.decl.Type = typeName(StringType)
+	return f.flag.String()
+}
+
+func (f *RecordFlag) Bool() *bool {
+	f.decl.Type = typeName(BoolType)
+	return f.flag.Bool()
+}
+
+func (f *RecordFlag) Int() *int {
+	f.decl.Type = typeName(IntType)
+	return f.flag.Int()
+}
+
+func (f *RecordFlag) Float64() *float64 {
+	f.decl.Type = typeName(FloatType)
+	return f.flag.Float64()
+}
+
+func (f *RecordFlag) Duration() *time.Duration {
+	f.decl.Type = typeName(DurationType)
+	return f.flag.Duration()
+}
+
+func (f *RecordFlag) Enum(options ...string) *string {
+	f.decl.Type = typeName(EnumType)
+	f.decl.Enum = options
+	return f.flag.Enum(options...)
+}
+
+func (f *RecordFlag) URL() **url.URL {
+	f.decl.Type = typeName(URLType)
+	return f.flag.URL()
+}
+
+func (f *RecordFlag) Strings() *[]string {
+	f.decl.Type = typeName(StringsType)
+	return f.flag.Strings()
+}
+
+func (f *RecordFlag) StringMap() *map[string]string {
+	f.decl.Type = typeName(MapType)
+	return f.flag.StringMap()
+}
+
+func (f *RecordFlag) Required() clier.FlagClauser {
+	f.decl.Required = true
+	f.flag.Required()
+	return f
+}
+
+func (f *RecordFlag) Short(p1 rune) clier.FlagClauser {
+	f.decl.Short = string(p1)
+	f.flag.Short(p1)
+	return f
+}
+
+func (f *RecordFlag) Hidden() clier.FlagClauser {
+	f.decl.Hidden = true
+	f.flag.Hidden()
+	return f
+}
+
+func (f *RecordFlag) Default(p1 string) clier.FlagClauser {
+	f.decl.Default = &p1
+	f.flag.Default(p1)
+	return f
+}
+
+func (f *RecordFlag) Envar(p1 string) clier.FlagClauser {
+	f.decl.Envar = p1
+	f.flag.Envar(p1)
+	return f
+}
+
+func (f *RecordFlag) SetValue(p1 clier.Valuer) clier.FlagClauser {
+	f.decl.Type = FixtureValueType
+	f.flag.SetValue(p1)
+	return f
+}
+
+// RecordArg is a recorded argument.
+type RecordArg struct {
+	arg  clier.ArgClauser
+	decl *FixtureDeclaration
+}
+
+func (a *RecordArg) Stringer() string {
+	return a.arg.Stringer()
+}
+
+func (a *RecordArg) String() *string {
+	a.decl.Type = typeName(StringType)
+	return a.arg.String()
+}
+
+func (a *RecordArg) Bool() *bool {
+	a.decl.Type = typeName(BoolType)
+	return a.arg.Bool()
+}
+
+func (a *RecordArg) Int() *int {
+	a.decl.Type = typeName(IntType)
+	return a.arg.Int()
+}
+
+func (a *RecordArg) Float64() *float64 {
+	a.decl.Type = typeName(FloatType)
+	return a.arg.Float64()
+}
+
+func (a *RecordArg) Duration() *time.Duration {
+	a.decl.Type = typeName(DurationType)
+	return a.arg.Duration()
+}
+
+func (a *RecordArg) Enum(options ...string) *string {
+	a.decl.Type = typeName(EnumType)
+	a.decl.Enum = options
+	return a.arg.Enum(options...)
+}
+
+func (a *RecordArg) URL() **url.URL {
+	a.decl.Type = typeName(URLType)
+	return a.arg.URL()
+}
+
+func (a *RecordArg) Required() clier.ArgClauser {
+	a.decl.Required = true
+	a.arg.Required()
+	return a
+}
+
+func (a *RecordArg) Default(p1 string) clier.ArgClauser {
+	a.decl.Default = &p1
+	a.arg.Default(p1)
+	return a
+}
+
+func (a *RecordArg) SetValue(p1 clier.Valuer) clier.ArgClauser {
+	a.decl.Type = FixtureValueType
+	a.arg.SetValue(p1)
+	return a
+}
+
+func (a *RecordArg) Envar(p1 string) clier.ArgClauser {
+	a.decl.Envar = p1
+	a.arg.Envar(p1)
+	return a
+}
+
+// RecordContext is the real backend context, given to the Recorder user.
+type RecordContext struct {
+	rec     *Recorder
+	context clier.ParseContexter
+}
+
+func (p *RecordContext) GetArgValue(a clier.ArgClauser) (interface{}, bool) {
+	if ra, ok := a.(*RecordArg); ok {
+		a = ra.arg
+	}
+	return p.context.GetArgValue(a)
+}
+
+func (p *RecordContext) GetFlagValue(f clier.FlagClauser) (interface{}, bool) {
+	if rf, ok := f.(*RecordFlag); ok {
+		f = rf.flag
+	}
+	return p.context.GetFlagValue(f)
+}
+
+func (p *RecordContext) GetArgSource(a clier.ArgClauser) string {
+	if ra, ok := a.(*RecordArg); ok {
+		a = ra.arg
+	}
+	return p.context.GetArgSource(a)
+}
+
+func (p *RecordContext) GetFlagSource(f clier.FlagClauser) string {
+	if rf, ok := f.(*RecordFlag); ok {
+		f = rf.flag
+	}
+	return p.context.GetFlagSource(f)
+}
+
+func (p *RecordContext) GetParam(name string) (interface{}, string) {
+	return p.context.GetParam(name)
+}
+
+// SelectedCommands return the recorded commands selected by the real backend context.
+func (p *RecordContext) SelectedCommands() (res []clier.CmdClauser) {
+	for _, cmd := range p.context.SelectedCommands() {
+		if rc := p.rec.root.find(cmd); rc != nil {
+			res = append(res, rc)
+		}
+	}
+	return
+}
+
+func (p *RecordContext) IsInvalidContext() bool {
+	return p == nil || p.context == nil || p.context.IsInvalidContext()
+}
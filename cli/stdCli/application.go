@@ -0,0 +1,133 @@
+package stdCli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// Application is a clier.Applicationer built on the go standard library only.
+type Application struct {
+	root   *CmdClause
+	name   string
+	writer io.Writer // Usage output on '--help'
+}
+
+// New creates the application interface. name is the application name displayed by Usage.
+func New(name, help string) *Application {
+	return &Application{root: newCmdClause(nil, name, help), name: name, writer: os.Stdout}
+}
+
+// UsageWriter set the writer used to display the usage on '--help'. Default is os.Stdout.
+func (a *Application) UsageWriter(w io.Writer) *Application {
+	a.writer = w
+	return a
+}
+
+func (a *Application) IsNil() bool {
+	if a == nil {
+		return true
+	}
+	return false
+}
+
+func (a *Application) Arg(p1, p2 string) clier.ArgClauser {
+	return a.root.Arg(p1, p2)
+}
+
+func (a *Application) Flag(p1, p2 string) clier.FlagClauser {
+	return a.root.Flag(p1, p2)
+}
+
+func (a *Application) Command(p1, p2 string) clier.CmdClauser {
+	return a.root.Command(p1, p2)
+}
+
+// ParseContext parses args with the current definition, without setting any values.
+//
+// It is lenient: unknown flags are collected (See ParseContext.UnknownFlags) as they can be declared later from the
+// context. Other errors are returned with the context parsed so far.
+func (a *Application) ParseContext(args []string) (p clier.ParseContexter, err error) {
+	context := newParseContext(a)
+	err = context.parse(args, false)
+	context.err = err
+	p = context
+	return
+}
+
+// Parse parses args and set all flags and arguments values, from the command line, ENV or defaults.
+// It returns the selected command full name.
+//
+// On '--help', the usage is displayed and no command is returned. (See UsageWriter)
+func (a *Application) Parse(args []string) (cmd string, err error) {
+	context := newParseContext(a)
+	if err = context.parse(args, true); err != nil {
+		return
+	}
+	if context.help {
+		a.Usage(a.writer)
+		return "", nil
+	}
+	selected := a.root
+	if len(context.cmds) > 0 {
+		selected = context.cmds[len(context.cmds)-1]
+	}
+	if len(selected.cmds) > 0 {
+		if selected == a.root {
+			return "", fmt.Errorf("command not specified")
+		}
+		return "", fmt.Errorf("must select a subcommand of %q", selected.FullCommand())
+	}
+	for _, c := range append([]*CmdClause{a.root}, context.cmds...) {
+		for _, f := range c.flags {
+			if err = f.set(context.flags[f]); err != nil {
+				return
+			}
+		}
+		for _, arg := range c.args {
+			v, found := context.args[arg]
+			if err = arg.set(v, found); err != nil {
+				return
+			}
+		}
+	}
+	return selected.FullCommand(), nil
+}
+
+func (a *Application) Name() string {
+	if a == nil {
+		return ""
+	}
+	return a.name
+}
+
+// Usage writes the application flags and commands. Hidden flags are not listed.
+func (a *Application) Usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: %s [<flags>] <command> [<args> ...]\n\n%s\n", a.name, a.root.help)
+	a.root.usage(w, 0)
+}
+
+// usage writes the command flags, arguments and sub commands, indented by level.
+func (c *CmdClause) usage(w io.Writer, level int) {
+	indent := strings.Repeat("  ", level)
+	for _, f := range c.flags {
+		if f.hidden {
+			continue
+		}
+		short := "   "
+		if f.short != 0 {
+			short = fmt.Sprintf("-%c,", f.short)
+		}
+		fmt.Fprintf(w, "%s  %s --%s  %s\n", indent, short, f.name, f.help)
+	}
+	for _, arg := range c.args {
+		fmt.Fprintf(w, "%s  <%s>  %s\n", indent, arg.name, arg.help)
+	}
+	for _, cmd := range c.cmds {
+		fmt.Fprintf(w, "%s%s  %s\n", indent, cmd.FullCommand(), cmd.help)
+		cmd.usage(w, level+1)
+	}
+}
@@ -0,0 +1,186 @@
+package stdCli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNilDetection(t *testing.T) {
+	var v *Application
+
+	t.Log("without new application, expect app.IsNil() to be true")
+	if !v.IsNil() {
+		t.Error("fail: ", v)
+	}
+	v = New("myapp", "")
+	t.Log("with a new application, expect app.IsNil() to be false")
+	if v.IsNil() {
+		t.Error("fail ", v)
+	}
+}
+
+func TestApplication_Name(t *testing.T) {
+	const app_name = "myapp"
+
+	t.Log("Expect Name() to return the stdCli application name.")
+	app := New(app_name, "")
+
+	if v := app.Name(); v != app_name {
+		t.Errorf("Expected app to be named '%s'. Got '%s'", app_name, v)
+	}
+}
+
+func TestApplication_Parse(t *testing.T) {
+	t.Log("Expect Parse() to set flags and arguments values and return the selected command.")
+
+	const (
+		test_help = "test help"
+		envar     = "STDCLI_TEST_ORGA"
+	)
+	// --- Setting test context ---
+	a := New("myapp", test_help)
+	debug := a.Flag("debug", test_help).Short('d').Bool()
+	verbose := a.Flag("verbose", test_help).Short('v').Bool()
+	color := a.Flag("color", test_help)
+	color.Default("true")
+	colorv := color.Bool()
+	c1 := a.Command("add", "")
+	c2 := c1.Command("repo", "")
+	name := c2.Arg("name", test_help).String()
+	count_arg := c2.Arg("count", test_help)
+	count_arg.Default("2")
+	count := count_arg.Int()
+	title := c2.Flag("title", test_help).Short('t').String()
+	orga_flag := c2.Flag("orga", test_help)
+	orga_flag.Envar(envar)
+	orga := orga_flag.String()
+	labels_flag := c2.Flag("label", test_help)
+	labels := labels_flag.Strings()
+	labels_flag.Default("a,b")
+	users := c2.Flag("user", test_help).Strings()
+	os.Setenv(envar, "myorga")
+	defer os.Unsetenv(envar)
+
+	// --- Run the test ---
+	cmd, err := a.Parse([]string{"-dv", "--no-color", "add", "repo", "myrepo", "-tmy title", "--user=u1", "--user", "u2"})
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected Parse() to work. Got %s", err)
+		return
+	}
+	if cmd != "add repo" {
+		t.Errorf("Expected Parse() to return 'add repo'. Got '%s'", cmd)
+	}
+	if !*debug || !*verbose || *colorv {
+		t.Errorf("Expected booleans to be set. Got debug=%t, verbose=%t, color=%t", *debug, *verbose, *colorv)
+	}
+	if *name != "myrepo" || *count != 2 || *title != "my title" {
+		t.Errorf("Expected values to be set. Got name='%s', count=%d, title='%s'", *name, *count, *title)
+	}
+	if *orga != "myorga" {
+		t.Errorf("Expected orga to be set from '%s'. Got '%s'", envar, *orga)
+	}
+	if strings.Join(*labels, ",") != "a,b" || strings.Join(*users, ",") != "u1,u2" {
+		t.Errorf("Expected repeatable flags to be set. Got labels=%v, users=%v", *labels, *users)
+	}
+}
+
+func TestApplication_Parse_Errors(t *testing.T) {
+	t.Log("Expect Parse() to report kingpin like errors.")
+
+	// --- Setting test context ---
+	newApp := func() *Application {
+		a := New("myapp", "")
+		c1 := a.Command("add", "")
+		c2 := c1.Command("repo", "")
+		c2.Arg("name", "").Required().String()
+		c2.Flag("kind", "").Enum("a", "b")
+		c2.Flag("count", "").Int()
+		c2.Flag("orga", "").Required().String()
+		return a
+	}
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{}, "command not specified"},
+		{[]string{"add"}, "must select a subcommand of \"add\""},
+		{[]string{"add", "repo", "--orga", "o"}, "required argument 'name' not provided"},
+		{[]string{"add", "repo", "myrepo"}, "required flag --orga not provided"},
+		{[]string{"add", "repo", "myrepo", "other", "--orga", "o"}, "unexpected other"},
+		{[]string{"add", "repo", "myrepo", "--orga", "o", "--kind", "c"}, "enum value must be one of a,b"},
+		{[]string{"add", "repo", "myrepo", "--orga", "o", "--count", "x"}, "invalid integer 'x'"},
+		{[]string{"add", "repo", "myrepo", "--unknown"}, "unknown long flag '--unknown'"},
+		{[]string{"add", "repo", "myrepo", "-u"}, "unknown short flag '-u'"},
+		{[]string{"del"}, "expected command but got \"del\""},
+	}
+
+	for _, test := range tests {
+		// --- Run the test ---
+		_, err := newApp().Parse(test.args)
+
+		// --- Start testing ---
+		if err == nil {
+			t.Errorf("Expected Parse(%v) to fail. Got no error.", test.args)
+		} else if !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("Expected Parse(%v) to fail with '%s'. Got '%s'", test.args, test.expected, err)
+		}
+	}
+}
+
+func TestApplication_Usage(t *testing.T) {
+	t.Log("Expect Usage() to list flags and commands, except hidden flags.")
+
+	// --- Setting test context ---
+	a := New("myapp", "my application")
+	a.Flag("debug", "debug mode").Short('d').Bool()
+	a.Flag("secret", "").Hidden().String()
+	a.Command("add", "add things").Command("repo", "add a repo")
+	out := new(bytes.Buffer)
+
+	// --- Run the test ---
+	a.Usage(out)
+
+	// --- Start testing ---
+	for _, expected := range []string{"usage: myapp", "-d, --debug  debug mode", "add repo  add a repo"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected usage to contain '%s'. Got:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out.String(), "--secret") {
+		t.Errorf("Expected usage to not list hidden flags. Got:\n%s", out)
+	}
+}
+
+func TestApplication_Parse_Help(t *testing.T) {
+	t.Log("Expect Parse() to display the usage on '--help' and to return no command.")
+
+	// --- Setting test context ---
+	a := New("myapp", "my application")
+	a.Flag("debug", "debug mode").Short('d').Bool()
+	a.Command("add", "add things").Command("repo", "add a repo").Arg("name", "repo name").Required().String()
+	out := new(bytes.Buffer)
+	a.UsageWriter(out)
+
+	// --- Run the test ---
+	cmd, err := a.Parse([]string{"add", "--help"})
+
+	// --- Start testing ---
+	if err != nil || cmd != "" {
+		t.Errorf("Expected Parse() to return no command and no error. Got '%s' (%s)", cmd, err)
+	}
+	if !strings.Contains(out.String(), "usage: myapp") {
+		t.Errorf("Expected the usage to be displayed. Got:\n%s", out)
+	}
+
+	// --- Run the test ---
+	p, err := a.ParseContext([]string{"--help", "add"})
+
+	// --- Start testing ---
+	if err != nil || len(p.SelectedCommands()) != 1 {
+		t.Errorf("Expected ParseContext() to ignore '--help'. Got %d commands (%s)", len(p.SelectedCommands()), err)
+	}
+}
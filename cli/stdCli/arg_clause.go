@@ -0,0 +1,127 @@
+package stdCli
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+type ArgClause struct {
+	name          string
+	help          string
+	required      bool
+	envar         string
+	default_value *string
+	value         clier.Valuer
+}
+
+func (a *ArgClause) Stringer() string {
+	ret := fmt.Sprintf("ArgClause (%p):\n", a)
+	ret += fmt.Sprintf("  name: '%s'\n", a.name)
+	if a.default_value == nil {
+		ret += fmt.Sprint("  vdefault: nil\n")
+	} else {
+		ret += fmt.Sprintf("  vdefault: '%s' (%p)\n", *a.default_value, a.default_value)
+	}
+	return ret
+}
+
+func (a *ArgClause) String() *string {
+	v := new(string)
+	a.value = &stringValue{v}
+	return v
+}
+
+func (a *ArgClause) Bool() *bool {
+	v := new(bool)
+	a.value = &boolValue{v}
+	return v
+}
+
+func (a *ArgClause) Int() *int {
+	v := new(int)
+	a.value = &intValue{v}
+	return v
+}
+
+func (a *ArgClause) Float64() *float64 {
+	v := new(float64)
+	a.value = &float64Value{v}
+	return v
+}
+
+func (a *ArgClause) Duration() *time.Duration {
+	v := new(time.Duration)
+	a.value = &durationValue{v}
+	return v
+}
+
+func (a *ArgClause) Enum(options ...string) *string {
+	v := new(string)
+	a.value = &enumValue{v, options}
+	return v
+}
+
+func (a *ArgClause) URL() **url.URL {
+	v := new(*url.URL)
+	a.value = &urlValue{v}
+	return v
+}
+
+func (a *ArgClause) Required() clier.ArgClauser {
+	a.required = true
+	return a
+}
+
+func (a *ArgClause) Default(p1 string) clier.ArgClauser {
+	if a.default_value == nil {
+		a.default_value = new(string)
+	}
+	*a.default_value = p1
+	return a
+}
+
+func (f *ArgClause) getDefaults() *string {
+	return f.default_value
+}
+
+func (f *ArgClause) hasDefaults() bool {
+	return (f.default_value != nil)
+}
+
+func (a *ArgClause) Envar(p1 string) clier.ArgClauser {
+	a.envar = p1
+	return a
+}
+
+func (a *ArgClause) SetValue(p1 clier.Valuer) clier.ArgClauser {
+	a.value = p1
+	return a
+}
+
+// set the argument value from cli, or if missing, ENV or if missing, defaults
+func (a *ArgClause) set(value string, found bool) error {
+	if a.value == nil {
+		a.String()
+	}
+	var values []string
+	if found {
+		values = []string{value}
+	} else if a.required && a.default_value == nil && getEnvar(a.envar) == "" {
+		return fmt.Errorf("required argument '%s' not provided", a.name)
+	}
+	if err := setValue(a.value, false, values, a.envar, a.default_value); err != nil {
+		return fmt.Errorf("argument '%s': %s", a.name, err)
+	}
+	return nil
+}
+
+// NewArg creates a generic ArgClause named name.
+func NewArg(name, help string) (a *ArgClause) {
+	a = new(ArgClause)
+	a.name = name
+	a.help = help
+	return
+}
@@ -0,0 +1,65 @@
+package stdCli
+
+import (
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// CmdClause is a command with its sub commands, flags and arguments.
+//
+// The application itself is a CmdClause without parent.
+type CmdClause struct {
+	name   string
+	help   string
+	parent *CmdClause
+	cmds   []*CmdClause
+	flags  []*FlagClause
+	args   []*ArgClause
+}
+
+func newCmdClause(parent *CmdClause, name, help string) *CmdClause {
+	return &CmdClause{parent: parent, name: name, help: help}
+}
+
+func (c *CmdClause) Command(p1, p2 string) clier.CmdClauser {
+	cmd := newCmdClause(c, p1, p2)
+	c.cmds = append(c.cmds, cmd)
+	return cmd
+}
+
+func (c *CmdClause) Flag(p1, p2 string) clier.FlagClauser {
+	f := NewFlag(p1, p2)
+	c.flags = append(c.flags, f)
+	return f
+}
+
+func (c *CmdClause) Arg(p1, p2 string) clier.ArgClauser {
+	a := NewArg(p1, p2)
+	c.args = append(c.args, a)
+	return a
+}
+
+// FullCommand return the command names from the application, like kingpin. Ex: 'add repo'
+func (c *CmdClause) FullCommand() string {
+	names := make([]string, 0, 2)
+	for cmd := c; cmd != nil && cmd.parent != nil; cmd = cmd.parent {
+		names = append([]string{cmd.name}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
+func (c *CmdClause) IsEqualTo(c_ref clier.CmdClauser) bool {
+	ref, ok := c_ref.(*CmdClause)
+	return ok && c == ref
+}
+
+// getCommand return the sub command named name. nil if not found.
+func (c *CmdClause) getCommand(name string) *CmdClause {
+	for _, cmd := range c.cmds {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
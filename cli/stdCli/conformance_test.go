@@ -0,0 +1,16 @@
+package stdCli
+
+import (
+	"testing"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+	"github.com/forj-oss/forjj-modules/cli/clier/cliertest"
+)
+
+func TestConformance(t *testing.T) {
+	t.Log("Expect stdCli to pass the clier conformance suite.")
+	cliertest.RunConformance(t, cliertest.ConformanceBackend{
+		New:  func() clier.Applicationer { return New("myapp", "") },
+		Args: cliertest.CommandLineArgs,
+	})
+}
@@ -0,0 +1,158 @@
+package stdCli
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+type FlagClause struct {
+	name          string
+	help          string
+	short         rune
+	hidden        bool
+	required      bool
+	envar         string
+	default_value *string
+	value         clier.Valuer
+	multi         bool // true if the flag is repeatable. (Strings, StringMap)
+	boolean       bool // true if the flag do not need a value. (--x, --no-x)
+}
+
+func (a *FlagClause) Stringer() string {
+	ret := fmt.Sprintf("FlagClause (%p):\n", a)
+	ret += fmt.Sprintf("  name: '%s'\n", a.name)
+	if a.default_value == nil {
+		ret += fmt.Sprint("  vdefault: nil\n")
+	} else {
+		ret += fmt.Sprintf("  vdefault: '%s' (%p)\n", *a.default_value, a.default_value)
+	}
+	return ret
+}
+
+func (f *FlagClause) String() *string {
+	v := new(string)
+	f.value = &stringValue{v}
+	return v
+}
+
+func (f *FlagClause) Bool() *bool {
+	v := new(bool)
+	f.value = &boolValue{v}
+	f.boolean = true
+	return v
+}
+
+func (f *FlagClause) Int() *int {
+	v := new(int)
+	f.value = &intValue{v}
+	return v
+}
+
+func (f *FlagClause) Float64() *float64 {
+	v := new(float64)
+	f.value = &float64Value{v}
+	return v
+}
+
+func (f *FlagClause) Duration() *time.Duration {
+	v := new(time.Duration)
+	f.value = &durationValue{v}
+	return v
+}
+
+func (f *FlagClause) Enum(options ...string) *string {
+	v := new(string)
+	f.value = &enumValue{v, options}
+	return v
+}
+
+func (f *FlagClause) URL() **url.URL {
+	v := new(*url.URL)
+	f.value = &urlValue{v}
+	return v
+}
+
+func (f *FlagClause) Strings() *[]string {
+	v := new([]string)
+	f.value = &stringsValue{v}
+	f.multi = true
+	return v
+}
+
+func (f *FlagClause) StringMap() *map[string]string {
+	v := new(map[string]string)
+	*v = make(map[string]string)
+	f.value = &stringMapValue{v}
+	f.multi = true
+	return v
+}
+
+func (f *FlagClause) isMulti() bool {
+	return f.multi
+}
+
+func (f *FlagClause) Required() clier.FlagClauser {
+	f.required = true
+	return f
+}
+
+func (f *FlagClause) Short(p1 rune) clier.FlagClauser {
+	f.short = p1
+	return f
+}
+
+func (f *FlagClause) Hidden() clier.FlagClauser {
+	f.hidden = true
+	return f
+}
+
+func (f *FlagClause) Default(p1 string) clier.FlagClauser {
+	if f.default_value == nil {
+		f.default_value = new(string)
+	}
+	*f.default_value = p1
+	return f
+}
+
+func (f *FlagClause) getDefaults() *string {
+	return f.default_value
+}
+
+func (f *FlagClause) hasDefaults() bool {
+	return (f.default_value != nil)
+}
+
+func (f *FlagClause) Envar(p1 string) clier.FlagClauser {
+	f.envar = p1
+	return f
+}
+
+func (f *FlagClause) SetValue(p1 clier.Valuer) clier.FlagClauser {
+	f.value = p1
+	return f
+}
+
+// set the flag value from cli values, or if missing, ENV or if missing, defaults
+func (f *FlagClause) set(values []string) error {
+	if f.value == nil {
+		f.String()
+	}
+	if len(values) == 0 && f.required && f.default_value == nil && getEnvar(f.envar) == "" {
+		return fmt.Errorf("required flag --%s not provided", f.name)
+	}
+	if err := setValue(f.value, f.multi, values, f.envar, f.default_value); err != nil {
+		return fmt.Errorf("flag '--%s': %s", f.name, err)
+	}
+	return nil
+}
+
+// NewFlag creates a generic FlagClause named name.
+func NewFlag(name, help string) (f *FlagClause) {
+	f = new(FlagClause)
+	f.name = name
+	f.help = help
+	return
+}
@@ -0,0 +1,252 @@
+package stdCli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+type ParseContext struct {
+	app     *Application
+	cmds    []*CmdClause
+	flags   map[*FlagClause][]string
+	args    map[*ArgClause]string
+	unknown []string
+	help    bool  // '--help' given, and not declared.
+	err     error // Parse error. (See IsInvalidContext)
+}
+
+func newParseContext(app *Application) *ParseContext {
+	p := new(ParseContext)
+	p.app = app
+	p.flags = make(map[*FlagClause][]string)
+	p.args = make(map[*ArgClause]string)
+	return p
+}
+
+// parse load commands, flags and arguments found in args.
+//
+// If strict is false, unknown flags are collected with their value, if any. An unknown flag takes the next word as
+// value, unless it is a flag, a sub command or the flag is given as '--name=value'. The first other error is
+// returned, but parsing continues.
+func (p *ParseContext) parse(args []string, strict bool) (err error) {
+	cur := p.app.root
+	flags := append([]*FlagClause{}, cur.flags...)
+	arg_index := 0
+	args_only := false
+	setError := func(e error) bool {
+		if err == nil {
+			err = e
+		}
+		return strict
+	}
+	// unknownFlag collects the unknown flag token and the next word as its value, if it can be one.
+	unknownFlag := func(i int, has_value bool) int {
+		p.unknown = append(p.unknown, args[i])
+		if !has_value && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") && cur.getCommand(args[i+1]) == nil {
+			i++
+			p.unknown = append(p.unknown, args[i])
+		}
+		return i
+	}
+
+	for i := 0; i < len(args); i++ {
+		token := args[i]
+
+		switch {
+		case args_only || token == "-" || !strings.HasPrefix(token, "-"):
+			if sub := cur.getCommand(token); sub != nil && !args_only && arg_index == 0 {
+				cur = sub
+				p.cmds = append(p.cmds, cur)
+				flags = append(flags, cur.flags...)
+			} else if len(cur.cmds) > 0 && !args_only {
+				if setError(fmt.Errorf("expected command but got %q", token)) {
+					return
+				}
+			} else if arg_index < len(cur.args) {
+				p.args[cur.args[arg_index]] = token
+				arg_index++
+			} else if setError(fmt.Errorf("unexpected %s", token)) {
+				return
+			}
+
+		case token == "--":
+			args_only = true
+
+		case strings.HasPrefix(token, "--"):
+			name, value, has_value := token[2:], "", false
+			if kv := strings.SplitN(name, "=", 2); len(kv) == 2 {
+				name, value, has_value = kv[0], kv[1], true
+			}
+			flag := findFlag(flags, func(f *FlagClause) bool { return f.name == name })
+			if flag == nil && strings.HasPrefix(name, "no-") && !has_value {
+				// --no-<flag> of a boolean flag.
+				if flag = findFlag(flags, func(f *FlagClause) bool { return f.name == name[3:] }); flag != nil {
+					if !flag.boolean {
+						flag = nil
+					} else {
+						value, has_value = "false", true
+					}
+				}
+			}
+			if flag == nil && name == "help" && !has_value {
+				p.help = true
+				continue
+			}
+			if flag == nil {
+				if strict {
+					return fmt.Errorf("unknown long flag '--%s'", name)
+				}
+				i = unknownFlag(i, has_value)
+				continue
+			}
+			if flag.boolean && !has_value {
+				value, has_value = "true", true
+			}
+			if !has_value {
+				if i+1 >= len(args) {
+					if setError(fmt.Errorf("expected argument for flag '--%s'", name)) {
+						return
+					}
+					continue
+				}
+				i++
+				value = args[i]
+			}
+			p.flags[flag] = append(p.flags[flag], value)
+
+		default:
+			// Short flags. Boolean short flags can be combined. Ex: -ab
+			shorts := []rune(token[1:])
+			for j := 0; j < len(shorts); j++ {
+				flag := findFlag(flags, func(f *FlagClause) bool { return f.short == shorts[j] })
+				if flag == nil {
+					if strict {
+						return fmt.Errorf("unknown short flag '-%c'", shorts[j])
+					}
+					i = unknownFlag(i, j+1 < len(shorts))
+					break
+				}
+				if flag.boolean {
+					p.flags[flag] = append(p.flags[flag], "true")
+					continue
+				}
+				value := strings.TrimPrefix(string(shorts[j+1:]), "=")
+				if value == "" {
+					if i+1 >= len(args) {
+						if setError(fmt.Errorf("expected argument for flag '-%c'", shorts[j])) {
+							return
+						}
+						break
+					}
+					i++
+					value = args[i]
+				}
+				p.flags[flag] = append(p.flags[flag], value)
+				break
+			}
+		}
+	}
+	return
+}
+
+// findFlag return the first flag matching.
+func findFlag(flags []*FlagClause, match func(*FlagClause) bool) *FlagClause {
+	for _, f := range flags {
+		if match(f) {
+			return f
+		}
+	}
+	return nil
+}
+
+// IsInvalidContext return true if the context is nil or if the command line parse has failed.
+func (p *ParseContext) IsInvalidContext() bool {
+	if p == nil || p.err != nil {
+		return true
+	}
+	return false
+}
+
+// UnknownFlags return the flags not declared when the context was parsed, with their values, as found in the command
+// line.
+func (p *ParseContext) UnknownFlags() []string {
+	if p == nil {
+		return nil
+	}
+	return p.unknown
+}
+
+// GetArgValue get value from cli, or if missing, ENV or if missing, defaults
+func (p *ParseContext) GetArgValue(a clier.ArgClauser) (interface{}, bool) {
+	v, source := p.getArgValue(a)
+	return v, source != ""
+}
+
+// GetArgSource return the source of the arg value. (See clier.ParseContexter)
+func (p *ParseContext) GetArgSource(a clier.ArgClauser) string {
+	_, source := p.getArgValue(a)
+	return source
+}
+
+func (p *ParseContext) getArgValue(a clier.ArgClauser) (interface{}, string) {
+	argClause := a.(*ArgClause)
+	if v, found := p.args[argClause]; found {
+		return v, clier.SourceCli
+	}
+	if v := getEnvar(argClause.envar); v != "" {
+		return v, clier.SourceEnvar
+	}
+	if argClause.hasDefaults() {
+		return argClause.getDefaults(), clier.SourceDefault
+	}
+	return nil, ""
+}
+
+// GetFlagValue get value from cli, or if missing, ENV or if missing, defaults
+//
+// Repeatable flags (Strings, StringMap) values are returned as []string. Their defaults are kept as *string.
+func (p *ParseContext) GetFlagValue(f clier.FlagClauser) (interface{}, bool) {
+	v, source := p.getFlagValue(f)
+	return v, source != ""
+}
+
+// GetFlagSource return the source of the flag value. (See clier.ParseContexter)
+func (p *ParseContext) GetFlagSource(f clier.FlagClauser) string {
+	_, source := p.getFlagValue(f)
+	return source
+}
+
+func (p *ParseContext) getFlagValue(f clier.FlagClauser) (interface{}, string) {
+	flagClause := f.(*FlagClause)
+	if values, found := p.flags[flagClause]; found {
+		if flagClause.isMulti() {
+			return append([]string{}, values...), clier.SourceCli
+		}
+		return values[len(values)-1], clier.SourceCli
+	}
+	if v := getEnvar(flagClause.envar); v != "" {
+		if flagClause.isMulti() {
+			return clier.EnvarValues(v), clier.SourceEnvar
+		}
+		return v, clier.SourceEnvar
+	}
+	if flagClause.hasDefaults() {
+		return flagClause.getDefaults(), clier.SourceDefault
+	}
+	return nil, ""
+}
+
+func (p *ParseContext) SelectedCommands() (res []clier.CmdClauser) {
+	res = make([]clier.CmdClauser, 0, len(p.cmds))
+	for _, cmd := range p.cmds {
+		res = append(res, cmd)
+	}
+	return
+}
+
+func (p *ParseContext) GetParam(param_name string) (ret interface{}, err string) {
+
+	return
+}
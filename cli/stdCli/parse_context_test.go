@@ -0,0 +1,135 @@
+package stdCli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseContext_GetArgValue(t *testing.T) {
+	t.Log("Expect ParseContext_GetArgValue() to return the appropriate context value.")
+
+	const (
+		test       = "test"
+		test_help  = "test help"
+		test_value = "test value"
+		myapp      = "myapp"
+	)
+	// --- Setting test context ---
+	a := New(myapp, test_help)
+	c1 := a.Command("add", "")
+	c2 := c1.Command("test", "")
+
+	a3 := c2.Arg("arg", test_help)
+	a3.String()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", test, test_value})
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected GetContext to not return an error. Got %s", err)
+		return
+	}
+
+	v, found := c.GetArgValue(a3)
+	if !found {
+		t.Errorf("Expected GetArgValue() to get '%s' arg. Not found", test)
+	}
+	if v != test_value {
+		t.Errorf("Expected GetArgValue() to get and return '%s'. But Got '%s'", test_value, v)
+	}
+	if cmds := c.SelectedCommands(); len(cmds) != 2 || cmds[1].FullCommand() != "add test" {
+		t.Errorf("Expected SelectedCommands() to return 'add' and 'add test'. Got %d commands", len(cmds))
+	}
+}
+
+func TestParseContext_GetFlagValue(t *testing.T) {
+	t.Log("Expect ParseContext_GetFlagValue() to return the appropriate context value.")
+
+	const (
+		test       = "test"
+		test_help  = "test help"
+		test_value = "test value"
+		myapp      = "myapp"
+	)
+	// --- Setting test context ---
+	a := New(myapp, test_help)
+	c1 := a.Command("add", "")
+	c2 := c1.Command("test", "")
+
+	f3 := c2.Flag("flag", test_help)
+	f3.String()
+	f4 := c2.Flag("default", test_help)
+	f4.Default(test_value)
+	f4.String()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", test, "--flag", test_value})
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected GetContext to not return an error. Got %s", err)
+		return
+	}
+
+	v, found := c.GetFlagValue(f3)
+	if !found {
+		t.Errorf("Expected GetFlagValue() to get '%s' arg. Not found", test)
+	}
+	if v != test_value {
+		t.Errorf("Expected GetFlagValue() to get and return '%s'. But Got '%s'", test_value, v)
+	}
+	if v, found := c.GetFlagValue(f4); !found || *v.(*string) != test_value {
+		t.Errorf("Expected GetFlagValue() to return the default value. Got %#v", v)
+	}
+}
+
+func TestParseContext_UnknownFlags(t *testing.T) {
+	t.Log("Expect ParseContext() to collect flags not declared yet.")
+
+	const test_value = "test value"
+	// --- Setting test context ---
+	a := New("myapp", "")
+	c1 := a.Command("add", "")
+	c2 := c1.Command("apps", "")
+	f := c2.Flag("apps", "")
+	f.Strings()
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"--later", "value", "add", "apps", "--infra-name=x", "--apps", test_value,
+		"--github-token", "secret", "-x", "--apps", "other"})
+
+	// --- Start testing ---
+	if err != nil {
+		t.Errorf("Expected ParseContext() to not return an error. Got %s", err)
+		return
+	}
+	if cmds := c.SelectedCommands(); len(cmds) != 2 {
+		t.Errorf("Expected 2 selected commands. Got %d", len(cmds))
+	}
+	v, found := c.GetFlagValue(f)
+	if l, ok := v.([]string); !found || !ok || len(l) != 2 || l[0] != test_value || l[1] != "other" {
+		t.Errorf("Expected GetFlagValue() to return the 2 apps values. Got %#v", v)
+	}
+	expected := "--later value --infra-name=x --github-token secret -x"
+	if unknown := strings.Join(c.(*ParseContext).UnknownFlags(), " "); unknown != expected {
+		t.Errorf("Expected unknown flags to be '%s'. Got '%s'", expected, unknown)
+	}
+}
+
+func TestParseContext_InvalidContext(t *testing.T) {
+	t.Log("Expect ParseContext() to return an invalid context when the parse fails.")
+
+	// --- Setting test context ---
+	a := New("myapp", "")
+	a.Command("add", "").Command("apps", "")
+
+	// --- Run the test ---
+	c, err := a.ParseContext([]string{"add", "unknown"})
+
+	// --- Start testing ---
+	if err == nil {
+		t.Error("Expected ParseContext() to fail. Got no error.")
+	}
+	if c == nil || !c.IsInvalidContext() {
+		t.Error("Expected the context to be invalid.")
+	}
+}
@@ -0,0 +1,184 @@
+package stdCli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/forj-oss/forjj-modules/cli/clier"
+)
+
+// resetter is implemented by repeatable values. Values are cleared before being set from the command line.
+type resetter interface {
+	reset()
+}
+
+type stringValue struct{ v *string }
+
+func (s *stringValue) Set(value string) error {
+	*s.v = value
+	return nil
+}
+
+func (s *stringValue) String() string { return *s.v }
+
+type boolValue struct{ v *bool }
+
+func (b *boolValue) Set(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid boolean '%s'", value)
+	}
+	*b.v = v
+	return nil
+}
+
+func (b *boolValue) String() string { return strconv.FormatBool(*b.v) }
+
+type intValue struct{ v *int }
+
+func (i *intValue) Set(value string) error {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid integer '%s'", value)
+	}
+	*i.v = v
+	return nil
+}
+
+func (i *intValue) String() string { return strconv.Itoa(*i.v) }
+
+type float64Value struct{ v *float64 }
+
+func (f *float64Value) Set(value string) error {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid float '%s'", value)
+	}
+	*f.v = v
+	return nil
+}
+
+func (f *float64Value) String() string { return strconv.FormatFloat(*f.v, 'g', -1, 64) }
+
+type durationValue struct{ v *time.Duration }
+
+func (d *durationValue) Set(value string) error {
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration '%s'", value)
+	}
+	*d.v = v
+	return nil
+}
+
+func (d *durationValue) String() string { return d.v.String() }
+
+type enumValue struct {
+	v       *string
+	options []string
+}
+
+func (e *enumValue) Set(value string) error {
+	for _, option := range e.options {
+		if value == option {
+			*e.v = value
+			return nil
+		}
+	}
+	return fmt.Errorf("enum value must be one of %s, got '%s'", strings.Join(e.options, ","), value)
+}
+
+func (e *enumValue) String() string { return *e.v }
+
+type urlValue struct{ v **url.URL }
+
+func (u *urlValue) Set(value string) error {
+	v, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %s", err)
+	}
+	*u.v = v
+	return nil
+}
+
+func (u *urlValue) String() string {
+	if *u.v == nil {
+		return ""
+	}
+	return (*u.v).String()
+}
+
+type stringsValue struct{ v *[]string }
+
+func (s *stringsValue) Set(value string) error {
+	*s.v = append(*s.v, value)
+	return nil
+}
+
+func (s *stringsValue) String() string { return strings.Join(*s.v, ",") }
+
+func (s *stringsValue) reset() { *s.v = []string{} }
+
+type stringMapValue struct{ v *map[string]string }
+
+func (s *stringMapValue) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 {
+		return fmt.Errorf("expected KEY=VALUE got '%s'", value)
+	}
+	(*s.v)[kv[0]] = kv[1]
+	return nil
+}
+
+func (s *stringMapValue) String() string {
+	pairs := make([]string, 0, len(*s.v))
+	for k, v := range *s.v {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (s *stringMapValue) reset() { *s.v = make(map[string]string) }
+
+// setValue set v from the command line values, or if missing, ENV or if missing, defaults.
+//
+// Repeatable values defaults are comma separated and envar values are new line separated, like kingpin do.
+func setValue(v clier.Valuer, multi bool, values []string, envar string, def *string) (err error) {
+	if len(values) == 0 {
+		if value := getEnvar(envar); value != "" {
+			values = []string{value}
+			if multi {
+				values = clier.EnvarValues(value)
+			}
+		} else if def != nil {
+			values = []string{*def}
+			if multi {
+				values = strings.Split(*def, ",")
+			}
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+	if r, ok := v.(resetter); ok {
+		r.reset()
+	}
+	for _, value := range values {
+		if err = v.Set(value); err != nil {
+			return fmt.Errorf("invalid value '%s': %s", value, err)
+		}
+	}
+	return
+}
+
+// getEnvar return the value of the environment variable envar. Empty if envar is not set.
+func getEnvar(envar string) string {
+	if envar == "" {
+		return ""
+	}
+	return os.Getenv(envar)
+}
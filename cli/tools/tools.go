@@ -3,6 +3,7 @@ package tools
 import (
 	"strconv"
 	"fmt"
+	"time"
 )
 
 // ToBoolWithAddr interpret a collection of type in Bool
@@ -54,3 +55,128 @@ func ToBool(value interface{}) (bool, error) {
 	}
 	return false, nil
 }
+
+// ToIntWithAddr interpret a collection of type in Int
+// If value type is a pointer, it will return a *int
+// else it will return an int
+func ToIntWithAddr(value interface{}) (interface{}, error) {
+	str := ""
+	addr := false
+	switch value.(type) {
+	case *string:
+		str = *value.(*string)
+		addr = true
+	case string:
+		str = value.(string)
+	case int, *int:
+		return value, nil
+	}
+
+	if i, err := strconv.Atoi(str); err != nil {
+		return nil, fmt.Errorf("Unable to interpret string as integer. %s", err)
+	} else {
+		if addr {
+			return &i, nil
+		}
+		return i, nil
+	}
+}
+
+// ToInt interpret a collection of type in Int
+func ToInt(value interface{}) (int, error) {
+	if i, err := ToIntWithAddr(value); err != nil {
+		return 0, err
+	} else {
+		switch i.(type) {
+		case int:
+			return i.(int), nil
+		case *int:
+			return *i.(*int), nil
+		}
+	}
+	return 0, nil
+}
+
+// ToFloatWithAddr interpret a collection of type in float64
+// If value type is a pointer, it will return a *float64
+// else it will return a float64
+func ToFloatWithAddr(value interface{}) (interface{}, error) {
+	str := ""
+	addr := false
+	switch value.(type) {
+	case *string:
+		str = *value.(*string)
+		addr = true
+	case string:
+		str = value.(string)
+	case float64, *float64:
+		return value, nil
+	}
+
+	if f, err := strconv.ParseFloat(str, 64); err != nil {
+		return nil, fmt.Errorf("Unable to interpret string as float. %s", err)
+	} else {
+		if addr {
+			return &f, nil
+		}
+		return f, nil
+	}
+}
+
+// ToFloat interpret a collection of type in float64
+func ToFloat(value interface{}) (float64, error) {
+	if f, err := ToFloatWithAddr(value); err != nil {
+		return 0, err
+	} else {
+		switch f.(type) {
+		case float64:
+			return f.(float64), nil
+		case *float64:
+			return *f.(*float64), nil
+		}
+	}
+	return 0, nil
+}
+
+// ToDurationWithAddr interpret a collection of type in time.Duration
+// If value type is a pointer, it will return a *time.Duration
+// else it will return a time.Duration
+//
+// Strings are interpreted with time.ParseDuration. (ex: 1h30m, 10s)
+func ToDurationWithAddr(value interface{}) (interface{}, error) {
+	str := ""
+	addr := false
+	switch value.(type) {
+	case *string:
+		str = *value.(*string)
+		addr = true
+	case string:
+		str = value.(string)
+	case time.Duration, *time.Duration:
+		return value, nil
+	}
+
+	if d, err := time.ParseDuration(str); err != nil {
+		return nil, fmt.Errorf("Unable to interpret string as duration. %s", err)
+	} else {
+		if addr {
+			return &d, nil
+		}
+		return d, nil
+	}
+}
+
+// ToDuration interpret a collection of type in time.Duration
+func ToDuration(value interface{}) (time.Duration, error) {
+	if d, err := ToDurationWithAddr(value); err != nil {
+		return 0, err
+	} else {
+		switch d.(type) {
+		case time.Duration:
+			return d.(time.Duration), nil
+		case *time.Duration:
+			return *d.(*time.Duration), nil
+		}
+	}
+	return 0, nil
+}
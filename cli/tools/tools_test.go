@@ -1,6 +1,9 @@
 package tools
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestToBoolWithAddr(t *testing.T) {
 	t.Log("Expect ToBoolWithAddr to return appropriate values and type")
@@ -180,3 +183,97 @@ func TestToBool(t *testing.T) {
 		}
 	}
 }
+
+func TestToIntWithAddr(t *testing.T) {
+	t.Log("Expect ToIntWithAddr to return appropriate values and type")
+
+	// --------------- Set Context
+
+	s := "42"
+	var val1 interface{} = &s
+
+	// --------------- running test
+	res, err := ToIntWithAddr(val1)
+	// --------------- Testing
+	if err != nil {
+		t.Errorf("Expected ToIntWithAddr(&'%s') to return a valid value. But got an error '%s'", s, err)
+	} else {
+		if v, ok := res.(*int); !ok {
+			t.Errorf("Expected ToIntWithAddr(&'%s') to get a *int type. Is not.", s)
+		} else if *v != 42 {
+			t.Errorf("Expected ToIntWithAddr(&'%s') to return 42. Got %d", s, *v)
+		}
+	}
+
+	// --------------- Set Context
+
+	val1 = s
+
+	// --------------- running test
+	res, err = ToIntWithAddr(val1)
+	// --------------- Testing
+	if err != nil {
+		t.Errorf("Expected ToIntWithAddr('%s') to return a valid value. But got an error '%s'", s, err)
+	} else if v, ok := res.(int); !ok || v != 42 {
+		t.Errorf("Expected ToIntWithAddr('%s') to return int 42. Got %#v", s, res)
+	}
+
+	// --------------- Set Context
+
+	val1 = "4.2"
+
+	// --------------- running test
+	_, err = ToIntWithAddr(val1)
+	// --------------- Testing
+	if err == nil {
+		t.Errorf("Expected ToIntWithAddr('%s') to fail. Got no error.", val1)
+	}
+}
+
+func TestToFloat(t *testing.T) {
+	t.Log("Expect ToFloat to return appropriate values")
+
+	// --------------- Set Context
+
+	s := "4.5"
+
+	// --------------- running test
+	res, err := ToFloat(&s)
+	// --------------- Testing
+	if err != nil {
+		t.Errorf("Expected ToFloat(&'%s') to return a valid value. But got an error '%s'", s, err)
+	} else if res != 4.5 {
+		t.Errorf("Expected ToFloat(&'%s') to return 4.5. Got %f", s, res)
+	}
+
+	// --------------- running test
+	_, err = ToFloat("four")
+	// --------------- Testing
+	if err == nil {
+		t.Error("Expected ToFloat('four') to fail. Got no error.")
+	}
+}
+
+func TestToDurationWithAddr(t *testing.T) {
+	t.Log("Expect ToDurationWithAddr to return appropriate values and type")
+
+	// --------------- Set Context
+
+	s := "1m30s"
+
+	// --------------- running test
+	res, err := ToDurationWithAddr(&s)
+	// --------------- Testing
+	if err != nil {
+		t.Errorf("Expected ToDurationWithAddr(&'%s') to return a valid value. But got an error '%s'", s, err)
+	} else if v, ok := res.(*time.Duration); !ok || *v != 90*time.Second {
+		t.Errorf("Expected ToDurationWithAddr(&'%s') to return *time.Duration 1m30s. Got %#v", s, res)
+	}
+
+	// --------------- running test
+	d, err := ToDuration("10")
+	// --------------- Testing
+	if err == nil {
+		t.Errorf("Expected ToDuration('10') to fail (missing unit). Got %s", d)
+	}
+}
@@ -1,29 +1,53 @@
 package cli
 
 import (
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// copyValue copy src in the flag/arg value address dest.
+// src is a value of the dest type, its address, or a string/[]string given by the cli context which is converted.
 func copyValue(src interface{}, dest interface{}) {
-	switch src.(type) {
+	switch dest.(type) {
 	case *int32:
 		dest_b := dest.(*int32)
-		src_b := src.(*int32)
-		*dest_b = *src_b
+		if src_b, ok := src.(*int32); ok {
+			*dest_b = *src_b
+		}
 	case *byte:
 		dest_b := dest.(*byte)
-		src_b := src.(*byte)
-		*dest_b = *src_b
+		*dest_b = to_byte(src)
 	case *bool:
 		dest_b := dest.(*bool)
-		src_b := src.(*bool)
-		*dest_b = *src_b
+		*dest_b = to_bool(src)
 	case *string:
 		dest_s := dest.(*string)
-		src_s := src.(*string)
-		*dest_s = *src_s
+		*dest_s = to_string(src)
+	case *int:
+		dest_i := dest.(*int)
+		*dest_i = to_int(src)
+	case *float64:
+		dest_f := dest.(*float64)
+		*dest_f = to_float(src)
+	case *time.Duration:
+		dest_d := dest.(*time.Duration)
+		*dest_d = to_duration(src)
+	case **url.URL:
+		dest_u := dest.(**url.URL)
+		*dest_u = to_url(src)
+	case *[]string:
+		dest_s := dest.(*[]string)
+		if values, ok := multiValues(src); ok {
+			*dest_s = append([]string{}, values...)
+		}
+	case *map[string]string:
+		dest_m := dest.(*map[string]string)
+		if v, err := toTypedValue(StringMap, src); err == nil {
+			*dest_m = to_string_map(v)
+		}
 	}
 }
 
@@ -66,6 +90,131 @@ func to_string(v interface{}) (result string) {
 	return
 }
 
+// simply extract int from the dynamic type. strings are converted.
+// otherwise the returned int is 0.
+func to_int(v interface{}) (result int) {
+	switch v.(type) {
+	case *int:
+		result = *v.(*int)
+	case int:
+		result = v.(int)
+	case *string, string:
+		result, _ = strconv.Atoi(to_string(v))
+	}
+	return
+}
+
+// simply extract float64 from the dynamic type. strings are converted.
+// otherwise the returned float is 0.
+func to_float(v interface{}) (result float64) {
+	switch v.(type) {
+	case *float64:
+		result = *v.(*float64)
+	case float64:
+		result = v.(float64)
+	case *string, string:
+		result, _ = strconv.ParseFloat(to_string(v), 64)
+	}
+	return
+}
+
+// simply extract time.Duration from the dynamic type. strings are converted.
+// otherwise the returned duration is 0.
+func to_duration(v interface{}) (result time.Duration) {
+	switch v.(type) {
+	case *time.Duration:
+		result = *v.(*time.Duration)
+	case time.Duration:
+		result = v.(time.Duration)
+	case *string, string:
+		result, _ = time.ParseDuration(to_string(v))
+	}
+	return
+}
+
+// simply extract *url.URL from the dynamic type. strings are parsed.
+// otherwise the returned url is nil.
+func to_url(v interface{}) (result *url.URL) {
+	switch v.(type) {
+	case **url.URL:
+		result = *v.(**url.URL)
+	case *url.URL:
+		result = v.(*url.URL)
+	case *string, string:
+		if s := to_string(v); s != "" {
+			result, _ = url.Parse(s)
+		}
+	}
+	return
+}
+
+// simply extract []string from the dynamic type.
+// otherwise the returned slice is nil.
+func to_string_slice(v interface{}) (result []string) {
+	switch v.(type) {
+	case *[]string:
+		result = *v.(*[]string)
+	case []string:
+		result = v.([]string)
+	}
+	return
+}
+
+// simply extract map[string]string from the dynamic type.
+// otherwise the returned map is nil.
+func to_string_map(v interface{}) (result map[string]string) {
+	switch v.(type) {
+	case *map[string]string:
+		result = *v.(*map[string]string)
+	case map[string]string:
+		result = v.(map[string]string)
+	}
+	return
+}
+
+// deref_value return the value pointed by a flag/arg value address. An URL is returned as string.
+// zero is true if the value is the type zero value. ok is false if the type is not supported.
+func deref_value(v interface{}) (value interface{}, zero bool, ok bool) {
+	ok = true
+	switch v.(type) {
+	case *string:
+		value = *v.(*string)
+		zero = (value.(string) == "")
+	case *bool:
+		value = *v.(*bool)
+		zero = !value.(bool)
+	case *int:
+		value = *v.(*int)
+		zero = (value.(int) == 0)
+	case *float64:
+		value = *v.(*float64)
+		zero = (value.(float64) == 0)
+	case *time.Duration:
+		value = *v.(*time.Duration)
+		zero = (value.(time.Duration) == 0)
+	case **url.URL:
+		if u := *v.(**url.URL); u != nil {
+			value = u.String()
+		} else {
+			value = ""
+			zero = true
+		}
+	case *[]string:
+		value = append([]string{}, *v.(*[]string)...)
+		zero = (len(value.([]string)) == 0)
+	case *map[string]string:
+		m := make(map[string]string)
+		for key, val := range *v.(*map[string]string) {
+			m[key] = val
+		}
+		value = m
+		zero = (len(m) == 0)
+	default:
+		ok = false
+	}
+	return
+}
+
 func is_string(v interface{}) bool {
 	switch v.(type) {
 	case *string, string: